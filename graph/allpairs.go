@@ -0,0 +1,203 @@
+package graph
+
+import "math"
+
+// Distances holds the least-cost distance between every pair of Nodes in a
+// graph, as computed by FloydWarshall or Johnson.
+type Distances struct {
+	index map[Node]int
+	dist  [][]float64
+}
+
+// Distance returns the least-cost distance from a to b, and false if there is
+// no route from a to b.
+func (d *Distances) Distance(a, b Node) (float64, bool) {
+	i, ok := d.index[a]
+	if !ok {
+		return 0, false
+	}
+	j, ok := d.index[b]
+	if !ok || math.IsInf(d.dist[i][j], 1) {
+		return 0, false
+	}
+	return d.dist[i][j], true
+}
+
+// Reachable returns true if a route from a to b exists. Every Node is
+// reachable from itself.
+func (d *Distances) Reachable(a, b Node) bool {
+	_, ok := d.Distance(a, b)
+	return ok
+}
+
+func newDistances(nodes []Node) *Distances {
+	d := &Distances{
+		index: make(map[Node]int, len(nodes)),
+		dist:  make([][]float64, len(nodes)),
+	}
+	for i, n := range nodes {
+		d.index[n] = i
+		d.dist[i] = make([]float64, len(nodes))
+		for j := range d.dist[i] {
+			d.dist[i][j] = math.Inf(1)
+		}
+		d.dist[i][i] = 0
+	}
+	return d
+}
+
+// FloydWarshall computes the least-cost distance between every pair of Nodes
+// by dynamic programming over intermediate Nodes, in O(V^3) time. Negative
+// edge weights are allowed, but if the IntGraph contains a negative-weight
+// cycle, it returns a NegativeCycleError listing the cycle.
+func (g *IntGraph) FloydWarshall() (*Distances, error) {
+	nodes := g.nodeList()
+	d := newDistances(nodes)
+	for i, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if j, ok := d.index[nbr]; ok {
+				d.dist[i][j] = min(d.dist[i][j], weight(n, nbr))
+			}
+		}
+	}
+	for k := range nodes {
+		for i := range nodes {
+			if math.IsInf(d.dist[i][k], 1) {
+				continue
+			}
+			for j := range nodes {
+				if through := d.dist[i][k] + d.dist[k][j]; through < d.dist[i][j] {
+					d.dist[i][j] = through
+				}
+			}
+		}
+	}
+	for i, n := range nodes {
+		if d.dist[i][i] < 0 {
+			_, err := g.BellmanFord(n)
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Johnson computes the least-cost distance between every pair of Nodes, in
+// O(V E log V) time, which beats FloydWarshall on sparse graphs. It reweights
+// edges to be non-negative using potentials found by Bellman-Ford, then runs
+// Dijkstra's algorithm from every Node. Negative edge weights are allowed, but
+// if the IntGraph contains a negative-weight cycle, it returns a
+// NegativeCycleError listing the cycle.
+func (g *IntGraph) Johnson() (*Distances, error) {
+	nodes := g.nodeList()
+	d := newDistances(nodes)
+	// Starting every potential at 0 is equivalent to relaxing from a virtual
+	// source with a zero-weight edge to every Node
+	h := make(map[Node]float64, len(nodes))
+	prev := map[Node]Node{}
+	for _, n := range nodes {
+		h[n] = 0
+	}
+	relax := func() Node {
+		var relaxed Node
+		for _, u := range nodes {
+			for _, v := range g.adjacent(u) {
+				if hv, ok := h[v]; ok && h[u]+weight(u, v) < hv {
+					h[v] = h[u] + weight(u, v)
+					prev[v] = u
+					relaxed = v
+				}
+			}
+		}
+		return relaxed
+	}
+	for i := 0; i < len(nodes); i++ {
+		if relax() == nil {
+			break
+		}
+	}
+	if v := relax(); v != nil {
+		return nil, NegativeCycleError{negativeCycle(prev, v, len(nodes))}
+	}
+	reweighted := func(u, v Node) float64 {
+		// Clamp the rounding error that can leave a zero-cost edge just below 0
+		return max(0, weight(u, v)+h[u]-h[v])
+	}
+	for i, n := range nodes {
+		dist, _, err := g.dijkstra(n, nil, reweighted)
+		if err != nil {
+			return nil, err
+		}
+		for m, dm := range dist {
+			if j, ok := d.index[m]; ok {
+				d.dist[i][j] = dm - h[n] + h[m]
+			}
+		}
+	}
+	return d, nil
+}
+
+// Closure is the transitive closure of a graph, answering whether any Node can
+// reach another in constant time. Nodes in the same strongly connected
+// component reach exactly the same Nodes, so they share a single bitset.
+type Closure struct {
+	index     map[Node]int
+	component map[Node]int
+	reach     [][]uint64
+}
+
+// Reachable returns true if a route from a to b exists. Every Node is
+// reachable from itself.
+func (c *Closure) Reachable(a, b Node) bool {
+	ca, ok := c.component[a]
+	if !ok {
+		return false
+	}
+	j, ok := c.index[b]
+	if !ok {
+		return false
+	}
+	return c.reach[ca][j/64]&(1<<(j%64)) != 0
+}
+
+// TransitiveClosure builds the Closure of the IntGraph. It condenses the
+// IntGraph into its strongly connected components, then unions each
+// component's reachable set from those of its successors, taking the
+// components in reverse topological order.
+func (g *IntGraph) TransitiveClosure() *Closure {
+	nodes := g.nodeList()
+	c := &Closure{
+		index:     make(map[Node]int, len(nodes)),
+		component: make(map[Node]int, len(nodes)),
+	}
+	for i, n := range nodes {
+		c.index[n] = i
+	}
+	// StronglyConnectedComponents returns components with every successor
+	// component ahead of its predecessors
+	components := g.StronglyConnectedComponents()
+	words := (len(nodes) + 63) / 64
+	c.reach = make([][]uint64, len(components))
+	for ci, component := range components {
+		for _, n := range component {
+			c.component[n] = ci
+		}
+	}
+	for ci, component := range components {
+		reach := make([]uint64, words)
+		for _, n := range component {
+			i := c.index[n]
+			reach[i/64] |= 1 << (i % 64)
+			for _, nbr := range g.adjacent(n) {
+				cj, ok := c.component[nbr]
+				if !ok || cj == ci {
+					continue
+				}
+				for w, bits := range c.reach[cj] {
+					reach[w] |= bits
+				}
+			}
+		}
+		c.reach[ci] = reach
+	}
+	return c
+}
@@ -0,0 +1,100 @@
+package graph
+
+import "testing"
+
+var allPairsTests = []struct {
+	nodes []int
+	edges [][]weightedEdge
+	err   bool
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 4}, {2, 5}},
+			[]weightedEdge{{3, 3}},
+			[]weightedEdge{{1, -3}},
+			[]weightedEdge{},
+		},
+		false,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 3}, {2, 8}, {4, -4}},
+			[]weightedEdge{{3, 1}, {4, 7}},
+			[]weightedEdge{{1, 4}},
+			[]weightedEdge{{0, 2}, {2, -5}},
+			[]weightedEdge{{3, 6}},
+		},
+		false,
+	},
+	{
+		[]int{0, 1, 2},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1}},
+			[]weightedEdge{{2, -1}},
+			[]weightedEdge{{0, -1}},
+		},
+		true,
+	},
+}
+
+func TestAllPairs(t *testing.T) {
+	for _, tt := range allPairsTests {
+		g, nodes := newWeightedIntGraph(tt.nodes, tt.edges)
+		for name, apsp := range map[string]func() (*Distances, error){
+			"FloydWarshall": g.FloydWarshall,
+			"Johnson":       g.Johnson,
+		} {
+			d, err := apsp()
+			if tt.err {
+				if _, ok := err.(NegativeCycleError); !ok {
+					t.Errorf("%s: expected NegativeCycleError, actual %v", name, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			// Every pair must agree with Bellman-Ford from the same source
+			for _, a := range nodes {
+				sp, _ := g.BellmanFord(a)
+				for _, b := range nodes {
+					exp, expOK := sp.Dist[b]
+					act, actOK := d.Distance(a, b)
+					if exp != act || expOK != actOK || d.Reachable(a, b) != expOK {
+						t.Errorf("%s: expected Distance(%v, %v) = %v (%v), actual %v (%v)", name, a, b, exp, expOK, act, actOK)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	for _, tt := range sccTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		c := g.TransitiveClosure()
+		for _, a := range nodes {
+			for _, b := range nodes {
+				if exp, act := g.RouteExists(a, b), c.Reachable(a, b); exp != act {
+					t.Errorf("Reachable(%v, %v): expected %v, actual %v", a, b, exp, act)
+				}
+			}
+		}
+		if c.Reachable(nodes[0], NewIntNode(0)) {
+			t.Errorf("Reachable: expected false for a node outside the graph")
+		}
+	}
+	g := ErdosRenyi(150, 2.0/150, false, 3)
+	c := g.TransitiveClosure()
+	for i := 0; i < 20; i++ {
+		a, _ := g.Get(i)
+		for _, b := range g.Nodes() {
+			if exp, act := g.RouteExists(a, b), c.Reachable(a, b); exp != act {
+				t.Errorf("Reachable(%v, %v): expected %v, actual %v", a, b, exp, act)
+			}
+		}
+	}
+}
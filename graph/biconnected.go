@@ -0,0 +1,116 @@
+package graph
+
+import "fmt"
+
+// biconnectivity holds the results of Tarjan's DFS over an undirected graph
+type biconnectivity struct {
+	articulation []Node
+	bridges      []Edge
+	components   [][]Edge
+}
+
+// biconnect finds the articulation points, bridges and biconnected components
+// of an undirected IntGraph in a single DFS, tracking for each Node its
+// discovery time and the earliest discovery time reachable from its subtree
+// through one back edge.
+func (g *IntGraph) biconnect(op string) (*biconnectivity, error) {
+	if !g.undirected {
+		return nil, fmt.Errorf("%s requires an undirected graph", op)
+	}
+	nodes := g.nodeList()
+	member := make(map[Node]struct{}, len(nodes))
+	for _, n := range nodes {
+		member[n] = struct{}{}
+	}
+	disc := map[Node]int{}
+	low := map[Node]int{}
+	stack := []Edge{}
+	b := &biconnectivity{
+		articulation: []Node{},
+		bridges:      []Edge{},
+		components:   [][]Edge{},
+	}
+	var visit func(u, parent Node)
+	visit = func(u, parent Node) {
+		disc[u] = len(disc)
+		low[u] = disc[u]
+		children := 0
+		cut := false
+		for _, v := range g.adjacent(u) {
+			if _, ok := member[v]; !ok || v == u || v == parent {
+				continue
+			}
+			if _, ok := disc[v]; ok {
+				if disc[v] < disc[u] {
+					// A back edge to an ancestor
+					stack = append(stack, Edge{From: u, To: v, Weight: weight(u, v)})
+					low[u] = min(low[u], disc[v])
+				}
+				continue
+			}
+			children++
+			stack = append(stack, Edge{From: u, To: v, Weight: weight(u, v)})
+			visit(v, u)
+			low[u] = min(low[u], low[v])
+			if low[v] > disc[u] {
+				b.bridges = append(b.bridges, Edge{From: u, To: v, Weight: weight(u, v)})
+			}
+			if low[v] >= disc[u] {
+				// u separates v's subtree from the rest of the graph, so the
+				// edges stacked since u-v form a biconnected component
+				cut = cut || parent != nil
+				component := []Edge{}
+				for {
+					e := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					component = append(component, e)
+					if e.From == u && e.To == v {
+						break
+					}
+				}
+				b.components = append(b.components, component)
+			}
+		}
+		if cut || (parent == nil && children > 1) {
+			b.articulation = append(b.articulation, u)
+		}
+	}
+	for _, n := range nodes {
+		if _, ok := disc[n]; !ok {
+			visit(n, nil)
+		}
+	}
+	return b, nil
+}
+
+// ArticulationPoints returns the Nodes of an undirected IntGraph whose removal
+// would disconnect part of the graph, i.e. its single points of failure.
+func (g *IntGraph) ArticulationPoints() ([]Node, error) {
+	b, err := g.biconnect("ArticulationPoints")
+	if err != nil {
+		return nil, err
+	}
+	return b.articulation, nil
+}
+
+// Bridges returns the edges of an undirected IntGraph whose removal would
+// disconnect part of the graph.
+func (g *IntGraph) Bridges() ([]Edge, error) {
+	b, err := g.biconnect("Bridges")
+	if err != nil {
+		return nil, err
+	}
+	return b.bridges, nil
+}
+
+// BiconnectedComponents returns the biconnected components of an undirected
+// IntGraph, each a maximal set of edges in which any two edges lie on a common
+// simple cycle, so that no single Node's removal disconnects them. A bridge
+// forms a component of its own.
+func (g *IntGraph) BiconnectedComponents() ([][]Edge, error) {
+	b, err := g.biconnect("BiconnectedComponents")
+	if err != nil {
+		return nil, err
+	}
+	return b.components, nil
+}
@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+)
+
+var biconnectedTests = []struct {
+	nodes        []int
+	edges        []undirectedEdge
+	articulation []int
+	bridges      [][2]int
+	components   []int
+}{
+	// Two triangles joined by the bridge 2-3
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[]undirectedEdge{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {2, 3, 1}, {3, 4, 1}, {4, 5, 1}, {5, 3, 1}},
+		[]int{2, 3},
+		[][2]int{{2, 3}},
+		[]int{1, 3, 3},
+	},
+	// A path, where every inner node and every edge is critical
+	{
+		[]int{0, 1, 2, 3},
+		[]undirectedEdge{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}},
+		[]int{1, 2},
+		[][2]int{{0, 1}, {1, 2}, {2, 3}},
+		[]int{1, 1, 1},
+	},
+	// A cycle has no single point of failure
+	{
+		[]int{0, 1, 2, 3},
+		[]undirectedEdge{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}},
+		[]int{},
+		[][2]int{},
+		[]int{4},
+	},
+	// Two squares sharing node 0, plus an isolated node
+	{
+		[]int{0, 1, 2, 3, 4, 5, 6, 7},
+		[]undirectedEdge{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {0, 4, 1}, {4, 5, 1}, {5, 6, 1}, {6, 0, 1}},
+		[]int{0},
+		[][2]int{},
+		[]int{4, 4},
+	},
+}
+
+func TestBiconnected(t *testing.T) {
+	for _, tt := range biconnectedTests {
+		g, _ := newUndirectedIntGraph(tt.nodes, tt.edges)
+		points, err := g.ArticulationPoints()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		act := pathValues(points)
+		sort.Ints(act)
+		if !equalInts(act, tt.articulation) {
+			t.Errorf("ArticulationPoints: expected %v, actual %v", tt.articulation, act)
+		}
+		bridges, _ := g.Bridges()
+		actBridges := [][2]int{}
+		for _, e := range bridges {
+			a, b := e.From.Value().(int), e.To.Value().(int)
+			actBridges = append(actBridges, [2]int{min(a, b), max(a, b)})
+		}
+		sort.Slice(actBridges, func(i, j int) bool { return actBridges[i][0] < actBridges[j][0] })
+		if len(actBridges) != len(tt.bridges) {
+			t.Errorf("Bridges: expected %v, actual %v", tt.bridges, actBridges)
+		}
+		for i := range tt.bridges {
+			if i < len(actBridges) && actBridges[i] != tt.bridges[i] {
+				t.Errorf("Bridges: expected %v, actual %v", tt.bridges, actBridges)
+			}
+		}
+		components, _ := g.BiconnectedComponents()
+		sizes := []int{}
+		for _, c := range components {
+			sizes = append(sizes, len(c))
+		}
+		sort.Ints(sizes)
+		if !equalInts(sizes, tt.components) {
+			t.Errorf("BiconnectedComponents: expected sizes %v, actual %v", tt.components, sizes)
+		}
+	}
+	if _, err := NewIntGraph().Bridges(); err == nil {
+		t.Errorf("Bridges: expected error for directed graph, actual nil")
+	}
+}
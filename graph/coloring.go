@@ -0,0 +1,192 @@
+package graph
+
+import "sort"
+
+// Colorings map each Node to a color 0, 1, 2, ... such that no edge joins two
+// Nodes of the same color. As with Bipartite, edges are treated as undirected.
+// A Node with an edge to itself can never be colored properly; the greedy
+// colorings ignore such self-loops, and ValidateColoring reports them.
+
+// colorAdjacency returns the undirected neighbors of each Node, without
+// self-loops.
+func (g *IntGraph) colorAdjacency(nodes []Node) map[Node][]Node {
+	adj := g.symmetricAdjacency(nodes)
+	for n, nbrs := range adj {
+		for i, nbr := range nbrs {
+			if nbr == n {
+				adj[n] = append(nbrs[:i:i], nbrs[i+1:]...)
+				break
+			}
+		}
+	}
+	return adj
+}
+
+// firstFit returns the least color not used by any of node's colored
+// neighbors.
+func firstFit(node Node, adj map[Node][]Node, color map[Node]int) int {
+	used := map[int]struct{}{}
+	for _, nbr := range adj[node] {
+		if c, ok := color[nbr]; ok {
+			used[c] = struct{}{}
+		}
+	}
+	c := 0
+	for {
+		if _, ok := used[c]; !ok {
+			return c
+		}
+		c++
+	}
+}
+
+// ColorCount returns the number of colors used by a coloring
+func ColorCount(color map[Node]int) int {
+	count := 0
+	for _, c := range color {
+		count = max(count, c+1)
+	}
+	return count
+}
+
+// WelshPowell returns a greedy coloring of the IntGraph that visits Nodes in
+// order of decreasing degree, giving each the least color its neighbors do not
+// use. It uses at most one more color than the greatest degree of any Node.
+func (g *IntGraph) WelshPowell() map[Node]int {
+	nodes := g.nodeList()
+	adj := g.colorAdjacency(nodes)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return len(adj[nodes[i]]) > len(adj[nodes[j]])
+	})
+	color := make(map[Node]int, len(nodes))
+	for _, n := range nodes {
+		color[n] = firstFit(n, adj, color)
+	}
+	return color
+}
+
+// DSatur returns a greedy coloring of the IntGraph that always colors next the
+// Node whose neighbors already use the most distinct colors, breaking ties by
+// degree. It colors bipartite graphs, cycles and wheels optimally, and often
+// uses fewer colors than WelshPowell elsewhere. It runs in O(V^2 + E) time.
+func (g *IntGraph) DSatur() map[Node]int {
+	nodes := g.nodeList()
+	adj := g.colorAdjacency(nodes)
+	color := make(map[Node]int, len(nodes))
+	saturation := make(map[Node]map[int]struct{}, len(nodes))
+	for _, n := range nodes {
+		saturation[n] = map[int]struct{}{}
+	}
+	for range nodes {
+		var next Node
+		for _, n := range nodes {
+			if _, ok := color[n]; ok {
+				continue
+			}
+			if next == nil || len(saturation[n]) > len(saturation[next]) ||
+				len(saturation[n]) == len(saturation[next]) && len(adj[n]) > len(adj[next]) {
+				next = n
+			}
+		}
+		c := firstFit(next, adj, color)
+		color[next] = c
+		for _, nbr := range adj[next] {
+			saturation[nbr][c] = struct{}{}
+		}
+	}
+	return color
+}
+
+// ValidateColoring checks that color is a proper coloring of the IntGraph. If
+// any edge joins two Nodes of the same color, or any Node has no color, it
+// returns a ColoringError listing them. Each conflicting edge of an undirected
+// IntGraph is listed once.
+func (g *IntGraph) ValidateColoring(color map[Node]int) error {
+	nodes := g.nodeList()
+	index := make(map[Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+	err := ColoringError{}
+	for i, n := range nodes {
+		c, ok := color[n]
+		if !ok {
+			err.Uncolored = append(err.Uncolored, n)
+			continue
+		}
+		for _, nbr := range g.adjacent(n) {
+			j, ok := index[nbr]
+			if !ok || g.undirected && j < i {
+				continue
+			}
+			if nc, ok := color[nbr]; ok && nc == c {
+				err.Conflicts = append(err.Conflicts, Edge{From: n, To: nbr, Weight: weight(n, nbr)})
+			}
+		}
+	}
+	if len(err.Conflicts) > 0 || len(err.Uncolored) > 0 {
+		return err
+	}
+	return nil
+}
+
+// ChromaticBounds returns bounds on the chromatic number of the IntGraph, the
+// least number of colors of any proper coloring. The lower bound is the size
+// of a clique found greedily, raised to 3 if the graph has an odd cycle; the
+// upper bound is the fewest colors used by WelshPowell, DSatur or, for a
+// bipartite graph, a two-coloring. Self-loops are ignored.
+func (g *IntGraph) ChromaticBounds() (lower, upper int) {
+	nodes := g.nodeList()
+	if len(nodes) == 0 {
+		return 0, 0
+	}
+	adj := g.colorAdjacency(nodes)
+	lower = greedyClique(nodes, adj)
+	upper = min(ColorCount(g.WelshPowell()), ColorCount(g.DSatur()))
+	if _, err := bipartite(nodes, adj); err == nil {
+		upper = min(upper, 2)
+	} else {
+		lower = max(lower, 3)
+	}
+	return lower, upper
+}
+
+// greedyClique returns the size of the largest clique found by growing one
+// from each Node, adding neighbors of decreasing degree that are adjacent to
+// every Node in the clique so far.
+func greedyClique(nodes []Node, adj map[Node][]Node) int {
+	best := 0
+	for _, n := range nodes {
+		if len(adj[n])+1 <= best {
+			continue
+		}
+		cands := append([]Node{}, adj[n]...)
+		sort.SliceStable(cands, func(i, j int) bool {
+			return len(adj[cands[i]]) > len(adj[cands[j]])
+		})
+		clique := []Node{n}
+		for _, c := range cands {
+			member := true
+			for _, m := range clique[1:] {
+				if !adjacentTo(adj, c, m) {
+					member = false
+					break
+				}
+			}
+			if member {
+				clique = append(clique, c)
+			}
+		}
+		best = max(best, len(clique))
+	}
+	return best
+}
+
+func adjacentTo(adj map[Node][]Node, a, b Node) bool {
+	for _, nbr := range adj[a] {
+		if nbr == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package graph
+
+import "testing"
+
+var coloringTests = []struct {
+	name  string
+	g     *IntGraph
+	lower int
+	upper int
+}{
+	{"Empty", NewUndirectedIntGraph(), 0, 0},
+	{"Edgeless", ErdosRenyi(5, 0, true, 1), 1, 1},
+	{"Tree", RandomTree(40, 3), 2, 2},
+	{"Grid", Grid(4, 5), 2, 2},
+	{"Complete", Complete(6, true), 6, 6},
+	{"Complete(directed)", Complete(4, false), 4, 4},
+	{"Cycle(5)", cycleIntGraph(5), 3, 3},
+	{"Cycle(6)", cycleIntGraph(6), 2, 2},
+}
+
+// cycleIntGraph builds an undirected cycle of n IntNodes
+func cycleIntGraph(n int) *IntGraph {
+	g, _ := generated(n, true)
+	for i := 0; i < n; i++ {
+		g.AddNeighborByID(i, (i+1)%n)
+	}
+	return g
+}
+
+func TestColoring(t *testing.T) {
+	for _, tt := range coloringTests {
+		for name, color := range map[string]map[Node]int{
+			"WelshPowell": tt.g.WelshPowell(),
+			"DSatur":      tt.g.DSatur(),
+		} {
+			if err := tt.g.ValidateColoring(color); err != nil {
+				t.Errorf("%v(%v): expected a proper coloring, actual %v", name, tt.name, err)
+			}
+			if c := ColorCount(color); c < tt.lower {
+				t.Errorf("%v(%v): expected at least %v colors, actual %v", name, tt.name, tt.lower, c)
+			}
+		}
+		if lower, upper := tt.g.ChromaticBounds(); lower != tt.lower || upper != tt.upper {
+			t.Errorf("ChromaticBounds(%v): expected [%v, %v], actual [%v, %v]", tt.name, tt.lower, tt.upper, lower, upper)
+		}
+	}
+}
+
+func TestColoringRandom(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		// Greedy colorings depend on the order Nodes are visited in
+		g := ErdosRenyi(80, 0.1, true, seed)
+		g.OrderByInsertion()
+		wp, ds := g.WelshPowell(), g.DSatur()
+		for _, color := range []map[Node]int{wp, ds} {
+			if err := g.ValidateColoring(color); err != nil {
+				t.Errorf("Coloring: expected a proper coloring, actual %v", err)
+			}
+		}
+		lower, upper := g.ChromaticBounds()
+		if lower > upper || upper > min(ColorCount(wp), ColorCount(ds)) {
+			t.Errorf("ChromaticBounds: expected lower <= upper <= %v, actual [%v, %v]", min(ColorCount(wp), ColorCount(ds)), lower, upper)
+		}
+	}
+}
+
+func TestValidateColoring(t *testing.T) {
+	g, nodes := newIntGraph([]int{0, 1, 2, 3}, [][]int{{1, 2}, {2}, {3}, {}})
+	color := map[Node]int{nodes[0]: 0, nodes[1]: 1, nodes[2]: 0}
+	err := g.ValidateColoring(color)
+	cerr, ok := err.(ColoringError)
+	if !ok {
+		t.Fatalf("ValidateColoring: expected ColoringError, actual %v", err)
+	}
+	if len(cerr.Conflicts) != 1 || cerr.Conflicts[0].From != nodes[0] || cerr.Conflicts[0].To != nodes[2] {
+		t.Errorf("ValidateColoring: expected conflict 0->2, actual %v", cerr.Conflicts)
+	}
+	if len(cerr.Uncolored) != 1 || cerr.Uncolored[0] != nodes[3] {
+		t.Errorf("ValidateColoring: expected 3 uncolored, actual %v", cerr.Uncolored)
+	}
+	// An undirected conflict is reported once, and a self-loop always is
+	u := cycleIntGraph(4)
+	zero, _ := u.Get(0)
+	u.AddNeighbor(zero, zero)
+	color = map[Node]int{}
+	for _, n := range u.Nodes() {
+		color[n] = 0
+	}
+	if err, _ := u.ValidateColoring(color).(ColoringError); len(err.Conflicts) != 5 {
+		t.Errorf("ValidateColoring: expected 5 conflicts, actual %v", err.Conflicts)
+	}
+	if err := u.ValidateColoring(u.DSatur()); err == nil {
+		t.Errorf("ValidateColoring: expected the self-loop to conflict, actual nil")
+	}
+}
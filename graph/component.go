@@ -0,0 +1,179 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/nikovacevic/ctci/unionfind"
+)
+
+// StronglyConnectedComponents returns the strongly connected components of the
+// IntGraph, each a set of Nodes that can all reach one another, using Tarjan's
+// algorithm. Components are returned in reverse topological order.
+func (g *IntGraph) StronglyConnectedComponents() [][]Node {
+	nodes := g.nodeList()
+	member := make(map[Node]struct{}, len(nodes))
+	for _, n := range nodes {
+		member[n] = struct{}{}
+	}
+	index := map[Node]int{}
+	lowlink := map[Node]int{}
+	onStack := map[Node]bool{}
+	stack := []Node{}
+	components := [][]Node{}
+	var strongConnect func(Node)
+	strongConnect = func(n Node) {
+		index[n] = len(index)
+		lowlink[n] = index[n]
+		stack = append(stack, n)
+		onStack[n] = true
+		for _, nbr := range g.adjacent(n) {
+			if _, ok := member[nbr]; !ok {
+				continue
+			}
+			if _, ok := index[nbr]; !ok {
+				strongConnect(nbr)
+				lowlink[n] = min(lowlink[n], lowlink[nbr])
+			} else if onStack[nbr] {
+				lowlink[n] = min(lowlink[n], index[nbr])
+			}
+		}
+		if lowlink[n] != index[n] {
+			return
+		}
+		component := []Node{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == n {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+	for _, n := range nodes {
+		if _, ok := index[n]; !ok {
+			strongConnect(n)
+		}
+	}
+	return components
+}
+
+// Kosaraju returns the strongly connected components of the IntGraph using
+// Kosaraju's algorithm: a DFS to order Nodes by finish time, followed by a DFS
+// of the transposed graph in reverse finish order. Components are returned in
+// topological order.
+func (g *IntGraph) Kosaraju() [][]Node {
+	nodes := g.nodeList()
+	reverse := make(map[Node][]Node, len(nodes))
+	for _, n := range nodes {
+		reverse[n] = []Node{}
+	}
+	for _, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if _, ok := reverse[nbr]; ok {
+				reverse[nbr] = append(reverse[nbr], n)
+			}
+		}
+	}
+	visited := map[Node]bool{}
+	finished := make([]Node, 0, len(nodes))
+	var visit func(Node)
+	visit = func(n Node) {
+		visited[n] = true
+		for _, nbr := range g.adjacent(n) {
+			if _, ok := reverse[nbr]; ok && !visited[nbr] {
+				visit(nbr)
+			}
+		}
+		finished = append(finished, n)
+	}
+	for _, n := range nodes {
+		if !visited[n] {
+			visit(n)
+		}
+	}
+	assigned := map[Node]bool{}
+	var collect func(Node, *[]Node)
+	collect = func(n Node, component *[]Node) {
+		assigned[n] = true
+		*component = append(*component, n)
+		for _, pred := range reverse[n] {
+			if !assigned[pred] {
+				collect(pred, component)
+			}
+		}
+	}
+	components := [][]Node{}
+	for i := len(finished) - 1; i >= 0; i-- {
+		if n := finished[i]; !assigned[n] {
+			component := []Node{}
+			collect(n, &component)
+			components = append(components, component)
+		}
+	}
+	return components
+}
+
+// Condensation returns the DAG formed by contracting each strongly connected
+// component of the IntGraph into a single IntNode, whose value is the index of
+// that component in components. Membership maps each original Node to its
+// component's IntNode. If the IntGraph is ordered, the DAG is ordered by
+// insertion, with edges added in the IntGraph's order.
+func (g *IntGraph) Condensation() (dag *IntGraph, components [][]Node, membership map[Node]*IntNode) {
+	components = g.StronglyConnectedComponents()
+	dag = NewIntGraph()
+	membership = map[Node]*IntNode{}
+	for i, component := range components {
+		c := NewIntNode(i)
+		dag.Insert(c)
+		for _, n := range component {
+			membership[n] = c
+		}
+	}
+	for _, component := range components {
+		for _, n := range component {
+			c := membership[n]
+			for _, nbr := range g.adjacent(n) {
+				if d, ok := membership[nbr]; ok && d != c {
+					c.AddNeighbor(d)
+				}
+			}
+		}
+	}
+	if ordered, _ := g.ordering(); ordered {
+		dag.OrderByInsertion()
+	}
+	return dag, components, membership
+}
+
+// Connectivity is an index of the connected components of an undirected
+// IntGraph, answering RouteExists queries in near-constant time. It reflects
+// the IntGraph at the time it was built, and is not updated by later changes.
+type Connectivity struct {
+	sets *unionfind.DisjointSet[Node]
+}
+
+// Connectivity builds a Connectivity index of an undirected IntGraph
+func (g *IntGraph) Connectivity() (*Connectivity, error) {
+	if !g.undirected {
+		return nil, fmt.Errorf("Connectivity requires an undirected graph")
+	}
+	nodes := g.nodeList()
+	sets := unionfind.New(nodes...)
+	for _, e := range g.undirectedEdges(nodes) {
+		sets.Union(e.From, e.To)
+	}
+	return &Connectivity{sets}, nil
+}
+
+// RouteExists returns true if a route from start to finish exists
+func (c *Connectivity) RouteExists(start, finish Node) bool {
+	return c.sets.Connected(start, finish)
+}
+
+// ComponentCount returns the number of connected components
+func (c *Connectivity) ComponentCount() int {
+	return c.sets.ComponentCount()
+}
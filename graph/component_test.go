@@ -0,0 +1,139 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+var sccTests = []struct {
+	nodes []int
+	edges [][]int
+	exp   [][]int
+}{
+	{
+		[]int{0, 1, 2, 3, 4, 5, 6, 7},
+		[][]int{
+			[]int{1},
+			[]int{2, 4, 5},
+			[]int{3, 6},
+			[]int{2, 7},
+			[]int{0, 5},
+			[]int{6},
+			[]int{5},
+			[]int{3, 6},
+		},
+		[][]int{
+			[]int{0, 1, 4},
+			[]int{2, 3, 7},
+			[]int{5, 6},
+		},
+	},
+	{
+		[]int{0, 1, 2},
+		[][]int{
+			[]int{1},
+			[]int{2},
+			[]int{},
+		},
+		[][]int{
+			[]int{0},
+			[]int{1},
+			[]int{2},
+		},
+	},
+}
+
+// componentValues converts components to sorted int values, with components
+// ordered by their smallest value.
+func componentValues(components [][]Node) [][]int {
+	values := make([][]int, len(components))
+	for i, c := range components {
+		values[i] = pathValues(c)
+		sort.Ints(values[i])
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i][0] < values[j][0] })
+	return values
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	for _, tt := range sccTests {
+		g, _ := newIntGraph(tt.nodes, tt.edges)
+		for name, scc := range map[string]func() [][]Node{
+			"StronglyConnectedComponents": g.StronglyConnectedComponents,
+			"Kosaraju":                    g.Kosaraju,
+		} {
+			act := componentValues(scc())
+			if len(act) != len(tt.exp) {
+				t.Errorf("%s: expected %v, actual %v", name, tt.exp, act)
+				continue
+			}
+			for i := range tt.exp {
+				if !equalInts(act[i], tt.exp[i]) {
+					t.Errorf("%s: expected %v, actual %v", name, tt.exp, act)
+				}
+			}
+		}
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	tt := sccTests[0]
+	g, nodes := newIntGraph(tt.nodes, tt.edges)
+	dag, components, membership := g.Condensation()
+	if dag.Size() != len(tt.exp) || len(components) != len(tt.exp) {
+		t.Errorf("Condensation: expected %v components, actual %v", len(tt.exp), dag.Size())
+	}
+	if _, err := dag.TopologicalSort(); err != nil {
+		t.Errorf("Condensation: expected a DAG, actual %v", err)
+	}
+	a, b, c := membership[nodes[0]], membership[nodes[2]], membership[nodes[5]]
+	if !a.HasNeighbor(b) || !a.HasNeighbor(c) || !b.HasNeighbor(c) || b.HasNeighbor(a) {
+		t.Errorf("Condensation: unexpected edges\n%v", dag)
+	}
+	if membership[nodes[1]] != a || membership[nodes[7]] != b {
+		t.Errorf("Condensation: expected nodes 0, 1 and 2, 7 to share components")
+	}
+}
+
+func TestCondensationOrder(t *testing.T) {
+	var exp string
+	for i := 0; i < 20; i++ {
+		g := ErdosRenyi(60, 0.03, false, 2)
+		g.OrderBy(ValueLess)
+		dag, _, _ := g.Condensation()
+		act := ""
+		for _, c := range dag.Nodes() {
+			act += fmt.Sprint(pathValues(c.(OrderedNode).OrderedNeighbors()))
+		}
+		if i == 0 {
+			exp = act
+		} else if act != exp {
+			t.Fatalf("Condensation: expected the same edge order on every run")
+		}
+	}
+}
+
+func TestConnectivity(t *testing.T) {
+	for _, tt := range mstTests {
+		g, nodes := newUndirectedIntGraph(tt.nodes, tt.edges)
+		c, err := g.Connectivity()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if act := c.ComponentCount(); act != len(tt.nodes)-tt.size {
+			t.Errorf("ComponentCount: expected %v, actual %v", len(tt.nodes)-tt.size, act)
+		}
+		for _, a := range nodes {
+			for _, b := range nodes {
+				if exp, act := g.RouteExists(a, b), c.RouteExists(a, b); exp != act {
+					t.Errorf("RouteExists(%v, %v): expected %v, actual %v", a, b, exp, act)
+				}
+			}
+		}
+	}
+	if _, err := NewIntGraph().Connectivity(); err == nil {
+		t.Errorf("Connectivity: expected error for directed graph, actual nil")
+	}
+}
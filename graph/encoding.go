@@ -0,0 +1,352 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// encodedEdge is an edge between Nodes identified by their encoding IDs
+type encodedEdge struct {
+	From   int         `json:"from"`
+	To     int         `json:"to"`
+	Weight float64     `json:"weight"`
+	Meta   interface{} `json:"meta,omitempty"`
+}
+
+// encodedNode is a Node identified by its encoding ID
+type encodedNode struct {
+	ID    int `json:"id"`
+	Value int `json:"value"`
+}
+
+// encodedGraph is the form in which an IntGraph is exported. Each Node is
+// given an ID by its position when the Nodes are ordered by value, so that
+// Nodes sharing a value are still told apart.
+type encodedGraph struct {
+	Directed bool          `json:"directed"`
+	Nodes    []encodedNode `json:"nodes"`
+	Edges    []encodedEdge `json:"edges"`
+}
+
+// encode orders the IntGraph's Nodes and edges for export. Nodes are ordered
+// by value, and Nodes sharing a value by insertion, so that the same graph is
+// always exported the same way. Edges of an undirected IntGraph are listed
+// once, from the lower ID to the higher.
+func (g *IntGraph) encode() (*encodedGraph, error) {
+	g.lock.Lock()
+	nodes := make([]Node, 0, len(g.nodes))
+	seq := make(map[Node]int, len(g.nodes))
+	for n, s := range g.nodes {
+		nodes = append(nodes, n)
+		seq[n] = s
+	}
+	g.lock.Unlock()
+	values := make(map[Node]int, len(nodes))
+	for _, n := range nodes {
+		v, ok := n.Value().(int)
+		if !ok {
+			return nil, fmt.Errorf("Cannot encode Node %v: value is not an int", n)
+		}
+		values[n] = v
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if values[nodes[i]] != values[nodes[j]] {
+			return values[nodes[i]] < values[nodes[j]]
+		}
+		return seq[nodes[i]] < seq[nodes[j]]
+	})
+	id := make(map[Node]int, len(nodes))
+	eg := &encodedGraph{
+		Directed: !g.undirected,
+		Nodes:    make([]encodedNode, len(nodes)),
+		Edges:    []encodedEdge{},
+	}
+	for i, n := range nodes {
+		id[n] = i
+		eg.Nodes[i] = encodedNode{i, values[n]}
+	}
+	for i, n := range nodes {
+		edges := []encodedEdge{}
+		for nbr := range n.Neighbors() {
+			j, ok := id[nbr]
+			if !ok || (g.undirected && j < i) {
+				continue
+			}
+			e := encodedEdge{From: i, To: j, Weight: 1}
+			if wn, ok := n.(WeightedNode); ok {
+				if edge, ok := wn.Edge(nbr); ok {
+					e.Weight, e.Meta = edge.Weight, edge.Meta
+				}
+			}
+			edges = append(edges, e)
+		}
+		sort.Slice(edges, func(a, b int) bool { return edges[a].To < edges[b].To })
+		eg.Edges = append(eg.Edges, edges...)
+	}
+	return eg, nil
+}
+
+// decode adds the Nodes and edges of the encoded graph to the empty IntGraph g
+func (eg *encodedGraph) decode(g *IntGraph) (*IntGraph, error) {
+	g.undirected = !eg.Directed
+	nodes := make(map[int]*IntNode, len(eg.Nodes))
+	for _, en := range eg.Nodes {
+		if _, ok := nodes[en.ID]; ok {
+			return nil, fmt.Errorf("Duplicate node ID: %v", en.ID)
+		}
+		nodes[en.ID] = NewIntNode(en.Value)
+		g.Insert(nodes[en.ID])
+		if !g.HasNode(nodes[en.ID]) {
+			return nil, fmt.Errorf("Duplicate node value in indexed graph: %v", en.Value)
+		}
+	}
+	for _, ee := range eg.Edges {
+		from, ok := nodes[ee.From]
+		if !ok {
+			return nil, fmt.Errorf("Edge references unknown node ID: %v", ee.From)
+		}
+		to, ok := nodes[ee.To]
+		if !ok {
+			return nil, fmt.Errorf("Edge references unknown node ID: %v", ee.To)
+		}
+		g.AddEdge(from, to, ee.Weight, ee.Meta)
+	}
+	return g, nil
+}
+
+// MarshalJSON encodes the IntGraph as a JSON object listing its Nodes, each
+// with an ID and value, and its edges between those IDs.
+func (g *IntGraph) MarshalJSON() ([]byte, error) {
+	eg, err := g.encode()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(eg)
+}
+
+// UnmarshalJSON replaces the contents of g with the graph encoded by
+// MarshalJSON. An indexed IntGraph stays indexed, and returns an error if two
+// encoded Nodes share a value.
+func (g *IntGraph) UnmarshalJSON(data []byte) error {
+	if g.frozen != nil {
+		return ReadOnlyError{}
+	}
+	var eg encodedGraph
+	if err := json.Unmarshal(data, &eg); err != nil {
+		return err
+	}
+	target := NewIntGraph()
+	if g.Indexed() {
+		target = NewIndexedIntGraph()
+	}
+	decoded, err := eg.decode(target)
+	if err != nil {
+		return err
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.nodes = decoded.nodes
+	g.seq = decoded.seq
+	g.index = decoded.index
+	g.undirected = decoded.undirected
+	return nil
+}
+
+// WriteDOT writes the IntGraph in the Graphviz DOT language. Nodes are named
+// by ID and labeled with their values, and edges are labeled with their
+// weights. Graphviz's own weight attribute is a non-negative integer layout
+// hint, so it is not used for edge weights.
+func (g *IntGraph) WriteDOT(w io.Writer) error {
+	eg, err := g.encode()
+	if err != nil {
+		return err
+	}
+	kind, arrow := "digraph", "->"
+	if !eg.Directed {
+		kind, arrow = "graph", "--"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s {\n", kind)
+	for _, en := range eg.Nodes {
+		fmt.Fprintf(bw, "\tn%d [label=\"%d\"];\n", en.ID, en.Value)
+	}
+	for _, ee := range eg.Edges {
+		fmt.Fprintf(bw, "\tn%d %s n%d [label=\"%s\"];\n", ee.From, arrow, ee.To, strconv.FormatFloat(ee.Weight, 'g', -1, 64))
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+var (
+	dotHeader = regexp.MustCompile(`^(di)?graph\s*(\w+\s*)?\{$`)
+	dotNode   = regexp.MustCompile(`^(\w+)\s*(\[(.*)\])?;?$`)
+	dotEdge   = regexp.MustCompile(`^(\w+)\s*(->|--)\s*(\w+)\s*(\[(.*)\])?;?$`)
+	dotAttr   = regexp.MustCompile(`(\w+)\s*=\s*"?([^",\s]*)"?`)
+)
+
+// ReadDOT builds an IntGraph from the subset of the Graphviz DOT language
+// written by WriteDOT: one node or edge statement per line. A node's value is
+// taken from its label attribute, and an edge's weight from its label
+// attribute too, defaulting to 1. Nodes referenced only by edges take their value
+// from their name, which must then be an integer.
+func ReadDOT(r io.Reader) (*IntGraph, error) {
+	eg := &encodedGraph{}
+	ids := map[string]int{}
+	names := []string{}
+	labels := map[string]string{}
+	node := func(name string) int {
+		if id, ok := ids[name]; ok {
+			return id
+		}
+		ids[name] = len(names)
+		names = append(names, name)
+		return ids[name]
+	}
+	scanner := bufio.NewScanner(r)
+	header := false
+	for line := 1; scanner.Scan(); line++ {
+		stmt := strings.TrimSpace(scanner.Text())
+		switch {
+		case stmt == "" || strings.HasPrefix(stmt, "//") || stmt == "}":
+			continue
+		case !header:
+			m := dotHeader.FindStringSubmatch(stmt)
+			if m == nil {
+				return nil, fmt.Errorf("DOT line %d: expected graph header, found %q", line, stmt)
+			}
+			eg.Directed = m[1] == "di"
+			header = true
+		case dotEdge.MatchString(stmt):
+			m := dotEdge.FindStringSubmatch(stmt)
+			if (m[2] == "->") != eg.Directed {
+				return nil, fmt.Errorf("DOT line %d: edge operator %s does not match graph type", line, m[2])
+			}
+			e := encodedEdge{From: node(m[1]), To: node(m[3]), Weight: 1}
+			attrs := dotAttrs(m[5])
+			if w, ok := attrs["label"]; ok {
+				weight, err := strconv.ParseFloat(w, 64)
+				if err != nil {
+					return nil, fmt.Errorf("DOT line %d: invalid weight %q", line, w)
+				}
+				e.Weight = weight
+			}
+			eg.Edges = append(eg.Edges, e)
+		case dotNode.MatchString(stmt):
+			m := dotNode.FindStringSubmatch(stmt)
+			node(m[1])
+			if label, ok := dotAttrs(m[3])["label"]; ok {
+				labels[m[1]] = label
+			}
+		default:
+			return nil, fmt.Errorf("DOT line %d: unrecognized statement %q", line, stmt)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !header {
+		return nil, fmt.Errorf("DOT: missing graph header")
+	}
+	// Nodes are inserted in the order they first appear
+	eg.Nodes = make([]encodedNode, 0, len(names))
+	for id, name := range names {
+		label, ok := labels[name]
+		if !ok {
+			label = name
+		}
+		value, err := strconv.Atoi(label)
+		if err != nil {
+			return nil, fmt.Errorf("DOT: node %s has non-integer value %q", name, label)
+		}
+		eg.Nodes = append(eg.Nodes, encodedNode{id, value})
+	}
+	return eg.decode(NewIntGraph())
+}
+
+func dotAttrs(list string) map[string]string {
+	attrs := map[string]string{}
+	for _, m := range dotAttr.FindAllStringSubmatch(list, -1) {
+		attrs[m[1]] = m[2]
+	}
+	return attrs
+}
+
+// WriteEdgeList writes the IntGraph as a plain edge list, one "from to weight"
+// line per edge, where from and to are Node values. Nodes without edges are
+// written as a line holding only their value.
+func (g *IntGraph) WriteEdgeList(w io.Writer) error {
+	eg, err := g.encode()
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	linked := make([]bool, len(eg.Nodes))
+	for _, ee := range eg.Edges {
+		linked[ee.From], linked[ee.To] = true, true
+		fmt.Fprintf(bw, "%d %d %s\n", eg.Nodes[ee.From].Value, eg.Nodes[ee.To].Value, strconv.FormatFloat(ee.Weight, 'g', -1, 64))
+	}
+	for i, en := range eg.Nodes {
+		if !linked[i] {
+			fmt.Fprintf(bw, "%d\n", en.Value)
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadEdgeList builds an IntGraph from a plain edge list, as written by
+// WriteEdgeList. Each line holds "from to [weight]", or a lone value for a
+// Node without edges; blank lines and lines starting with # are ignored. Since
+// Nodes are identified by value, the IntGraph returned is indexed.
+func ReadEdgeList(r io.Reader, undirected bool) (*IntGraph, error) {
+	eg := &encodedGraph{Directed: !undirected}
+	ids := map[int]int{}
+	node := func(value int) int {
+		if id, ok := ids[value]; ok {
+			return id
+		}
+		ids[value] = len(eg.Nodes)
+		eg.Nodes = append(eg.Nodes, encodedNode{ids[value], value})
+		return ids[value]
+	}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("Edge list line %d: expected at most 3 fields, found %d", line, len(fields))
+		}
+		values := make([]int, 0, 2)
+		for _, f := range fields[:min(len(fields), 2)] {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("Edge list line %d: invalid node value %q", line, f)
+			}
+			values = append(values, v)
+		}
+		if len(values) == 1 {
+			node(values[0])
+			continue
+		}
+		e := encodedEdge{From: node(values[0]), To: node(values[1]), Weight: 1}
+		if len(fields) == 3 {
+			w, err := strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return nil, fmt.Errorf("Edge list line %d: invalid weight %q", line, fields[2])
+			}
+			e.Weight = w
+		}
+		eg.Edges = append(eg.Edges, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return eg.decode(NewIndexedIntGraph())
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var encodingTests = []struct {
+	nodes []int
+	edges [][]weightedEdge
+}{
+	{
+		[]int{0, 1, 2},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1}, {2, 5}},
+			[]weightedEdge{{2, 1.5}},
+			[]weightedEdge{},
+		},
+	},
+	{
+		[]int{4, 3, 2, 1, 0},
+		[][]weightedEdge{
+			[]weightedEdge{{1, -4}, {4, 1}},
+			[]weightedEdge{{0, 2}},
+			[]weightedEdge{{2, 1}},
+			[]weightedEdge{},
+			[]weightedEdge{},
+		},
+	},
+}
+
+func TestWriteDOT(t *testing.T) {
+	g, _ := newWeightedIntGraph(encodingTests[0].nodes, encodingTests[0].edges)
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	exp := `digraph {
+	n0 [label="0"];
+	n1 [label="1"];
+	n2 [label="2"];
+	n0 -> n1 [label="1"];
+	n0 -> n2 [label="5"];
+	n1 -> n2 [label="1.5"];
+}
+`
+	if act := buf.String(); act != exp {
+		t.Errorf("WriteDOT: expected\n%v\nactual\n%v", exp, act)
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	for _, tt := range encodingTests {
+		g, _ := newWeightedIntGraph(tt.nodes, tt.edges)
+		var exp bytes.Buffer
+		g.WriteEdgeList(&exp)
+		for name, roundTrip := range map[string]func(*IntGraph) (*IntGraph, error){
+			"DOT": func(g *IntGraph) (*IntGraph, error) {
+				var buf bytes.Buffer
+				if err := g.WriteDOT(&buf); err != nil {
+					return nil, err
+				}
+				return ReadDOT(&buf)
+			},
+			"JSON": func(g *IntGraph) (*IntGraph, error) {
+				data, err := json.Marshal(g)
+				if err != nil {
+					return nil, err
+				}
+				h := NewIntGraph()
+				return h, json.Unmarshal(data, h)
+			},
+			"EdgeList": func(g *IntGraph) (*IntGraph, error) {
+				var buf bytes.Buffer
+				if err := g.WriteEdgeList(&buf); err != nil {
+					return nil, err
+				}
+				return ReadEdgeList(&buf, false)
+			},
+		} {
+			h, err := roundTrip(g)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			var act bytes.Buffer
+			h.WriteEdgeList(&act)
+			if h.Size() != g.Size() || act.String() != exp.String() {
+				t.Errorf("%s: expected\n%v\nactual\n%v", name, exp.String(), act.String())
+			}
+		}
+	}
+}
+
+func TestJSONNodeIdentity(t *testing.T) {
+	// Two distinct nodes sharing a value must survive a round trip
+	g := NewUndirectedIntGraph()
+	a, b, c := NewIntNode(7), NewIntNode(7), NewIntNode(8)
+	g.Insert(a)
+	g.Insert(b)
+	g.Insert(c)
+	g.AddEdge(a, c, 2, "primary")
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewIntGraph()
+	if err := json.Unmarshal(data, h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Size() != 3 || !h.Undirected() {
+		t.Errorf("UnmarshalJSON: expected 3 undirected nodes, actual %v (undirected=%v)", h.Size(), h.Undirected())
+	}
+	edges := 0
+	for _, n := range h.nodeList() {
+		for nbr := range n.Neighbors() {
+			e, _ := n.(WeightedNode).Edge(nbr)
+			if e.Weight != 2 || e.Meta != "primary" {
+				t.Errorf("UnmarshalJSON: expected weight 2 (primary), actual %v (%v)", e.Weight, e.Meta)
+			}
+			edges++
+		}
+	}
+	if edges != 2 {
+		t.Errorf("UnmarshalJSON: expected a single undirected edge, actual %v directed edges", edges)
+	}
+}
+
+func TestEncodingStable(t *testing.T) {
+	// Nodes sharing a value are told apart by insertion order
+	var exp bytes.Buffer
+	for i := 0; i < 20; i++ {
+		g := NewIntGraph()
+		a, b, c := NewIntNode(1), NewIntNode(1), NewIntNode(2)
+		g.Insert(a)
+		g.Insert(b)
+		g.Insert(c)
+		b.AddNeighbor(c)
+		var act bytes.Buffer
+		g.WriteDOT(&act)
+		if i == 0 {
+			exp = act
+		} else if act.String() != exp.String() {
+			t.Fatalf("WriteDOT: expected\n%v\nactual\n%v", exp.String(), act.String())
+		}
+	}
+	// ReadDOT inserts Nodes in the order they first appear
+	input := "digraph {\n\tn3 [label=\"3\"];\n\tn1 [label=\"1\"];\n\tn1 -> 2;\n}\n"
+	for i := 0; i < 20; i++ {
+		g, err := ReadDOT(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.OrderByInsertion()
+		if act := pathValues(g.Nodes()); !equalInts(act, []int{3, 1, 2}) {
+			t.Fatalf("ReadDOT: expected insertion order [3 1 2], actual %v", act)
+		}
+	}
+}
+
+func TestJSONIndexed(t *testing.T) {
+	g, _ := newIntGraph([]int{3, 1, 2}, [][]int{{1}, {2}, {}})
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewIndexedIntGraph()
+	if err := json.Unmarshal(data, h); err != nil {
+		t.Fatal(err)
+	}
+	if !h.Indexed() || h.AddNeighborByID(2, 3) != nil {
+		t.Errorf("UnmarshalJSON: expected an indexed graph, actual indexed=%v", h.Indexed())
+	}
+	g.Insert(NewIntNode(3))
+	data, _ = json.Marshal(g)
+	if err := json.Unmarshal(data, NewIndexedIntGraph()); err == nil {
+		t.Errorf("UnmarshalJSON: expected error for duplicate values in an indexed graph, actual nil")
+	}
+}
+
+var readErrorTests = []struct {
+	format string
+	input  string
+}{
+	{"DOT", "n0 -> n1;\n"},
+	{"DOT", "digraph {\n\ta -- b;\n}\n"},
+	{"DOT", "digraph {\n\ta [label=\"x\"];\n}\n"},
+	{"EdgeList", "1 2 3 4\n"},
+	{"EdgeList", "1 b\n"},
+	{"EdgeList", "1 2 heavy\n"},
+}
+
+func TestReadErrors(t *testing.T) {
+	for _, tt := range readErrorTests {
+		var err error
+		switch tt.format {
+		case "DOT":
+			_, err = ReadDOT(strings.NewReader(tt.input))
+		case "EdgeList":
+			_, err = ReadEdgeList(strings.NewReader(tt.input), false)
+		}
+		if err == nil {
+			t.Errorf("Read%s(%q): expected error, actual nil", tt.format, tt.input)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+)
+
+// flowEpsilon is the residual capacity below which an arc is considered
+// saturated, absorbing floating point error.
+const flowEpsilon = 1e-9
+
+// Flow is a maximum flow from Source to Sink through a capacitated, directed
+// graph, where each edge's weight is its capacity. Edges holds the flow along
+// every edge of the graph, and SourceSide and SinkSide partition the Nodes
+// into a minimum cut whose capacity equals Value.
+type Flow struct {
+	Source     Node
+	Sink       Node
+	Value      float64
+	Edges      []EdgeFlow
+	SourceSide []Node
+	SinkSide   []Node
+}
+
+// EdgeFlow is the flow along a single Edge
+type EdgeFlow struct {
+	Edge Edge
+	Flow float64
+}
+
+// flowNetwork is the residual network of an IntGraph. Arcs are stored in
+// pairs, so that arc i^1 is the reverse of arc i.
+type flowNetwork struct {
+	nodes  []Node
+	index  map[Node]int
+	adj    [][]int
+	to     []int
+	cap    []float64
+	flow   []float64
+	edges  []Edge
+	source int
+	sink   int
+}
+
+func (g *IntGraph) flowNetwork(source, sink Node) (*flowNetwork, error) {
+	if !g.HasNode(source) {
+		return nil, MissingNodeError{g, source}
+	}
+	if !g.HasNode(sink) {
+		return nil, MissingNodeError{g, sink}
+	}
+	if source == sink {
+		return nil, fmt.Errorf("Flow source and sink must differ: %v", source)
+	}
+	nodes := g.nodeList()
+	fn := &flowNetwork{
+		nodes: nodes,
+		index: make(map[Node]int, len(nodes)),
+		adj:   make([][]int, len(nodes)),
+	}
+	for i, n := range nodes {
+		fn.index[n] = i
+	}
+	for u, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			v, ok := fn.index[nbr]
+			if !ok || u == v {
+				continue
+			}
+			c := weight(n, nbr)
+			if c < 0 {
+				return nil, fmt.Errorf("Flow capacities must be non-negative: %v->%v has capacity %v", n, nbr, c)
+			}
+			fn.adj[u] = append(fn.adj[u], len(fn.to))
+			fn.to = append(fn.to, v)
+			fn.cap = append(fn.cap, c)
+			fn.adj[v] = append(fn.adj[v], len(fn.to))
+			fn.to = append(fn.to, u)
+			fn.cap = append(fn.cap, 0)
+			fn.edges = append(fn.edges, Edge{From: n, To: nbr, Weight: c})
+		}
+	}
+	fn.flow = make([]float64, len(fn.to))
+	fn.source, fn.sink = fn.index[source], fn.index[sink]
+	return fn, nil
+}
+
+func (fn *flowNetwork) residual(arc int) float64 {
+	return fn.cap[arc] - fn.flow[arc]
+}
+
+func (fn *flowNetwork) push(arc int, amount float64) {
+	fn.flow[arc] += amount
+	fn.flow[arc^1] -= amount
+}
+
+// levels returns the BFS distance of each node from the source through arcs
+// with residual capacity, or -1 for unreachable nodes.
+func (fn *flowNetwork) levels() []int {
+	level := make([]int, len(fn.nodes))
+	for i := range level {
+		level[i] = -1
+	}
+	level[fn.source] = 0
+	queue := []int{fn.source}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, arc := range fn.adj[u] {
+			if v := fn.to[arc]; level[v] < 0 && fn.residual(arc) > flowEpsilon {
+				level[v] = level[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return level
+}
+
+// result summarizes the network once no augmenting path remains
+func (fn *flowNetwork) result() *Flow {
+	f := &Flow{
+		Source:     fn.nodes[fn.source],
+		Sink:       fn.nodes[fn.sink],
+		Edges:      make([]EdgeFlow, len(fn.edges)),
+		SourceSide: []Node{},
+		SinkSide:   []Node{},
+	}
+	for i, e := range fn.edges {
+		f.Edges[i] = EdgeFlow{e, fn.flow[2*i]}
+	}
+	for _, arc := range fn.adj[fn.source] {
+		f.Value += fn.flow[arc]
+	}
+	for i, l := range fn.levels() {
+		if l >= 0 {
+			f.SourceSide = append(f.SourceSide, fn.nodes[i])
+		} else {
+			f.SinkSide = append(f.SinkSide, fn.nodes[i])
+		}
+	}
+	return f
+}
+
+// EdmondsKarp returns a maximum flow from source to sink, treating each edge's
+// weight as its capacity, by repeatedly augmenting along shortest paths.
+func (g *IntGraph) EdmondsKarp(source, sink Node) (*Flow, error) {
+	fn, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		via := make([]int, len(fn.nodes))
+		for i := range via {
+			via[i] = -1
+		}
+		queue := []int{fn.source}
+		for len(queue) > 0 && via[fn.sink] < 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, arc := range fn.adj[u] {
+				if v := fn.to[arc]; v != fn.source && via[v] < 0 && fn.residual(arc) > flowEpsilon {
+					via[v] = arc
+					queue = append(queue, v)
+				}
+			}
+		}
+		if via[fn.sink] < 0 {
+			return fn.result(), nil
+		}
+		bottleneck := -1.0
+		for v := fn.sink; v != fn.source; v = fn.to[via[v]^1] {
+			if r := fn.residual(via[v]); bottleneck < 0 || r < bottleneck {
+				bottleneck = r
+			}
+		}
+		for v := fn.sink; v != fn.source; v = fn.to[via[v]^1] {
+			fn.push(via[v], bottleneck)
+		}
+	}
+}
+
+// Dinic returns a maximum flow from source to sink, treating each edge's
+// weight as its capacity, by saturating blocking flows in the level graph.
+func (g *IntGraph) Dinic(source, sink Node) (*Flow, error) {
+	fn, err := g.flowNetwork(source, sink)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		level := fn.levels()
+		if level[fn.sink] < 0 {
+			return fn.result(), nil
+		}
+		next := make([]int, len(fn.nodes))
+		var augment func(u int, limit float64) float64
+		augment = func(u int, limit float64) float64 {
+			if u == fn.sink {
+				return limit
+			}
+			for ; next[u] < len(fn.adj[u]); next[u]++ {
+				arc := fn.adj[u][next[u]]
+				v := fn.to[arc]
+				if level[v] != level[u]+1 || fn.residual(arc) <= flowEpsilon {
+					continue
+				}
+				if pushed := augment(v, min(limit, fn.residual(arc))); pushed > flowEpsilon {
+					fn.push(arc, pushed)
+					return pushed
+				}
+			}
+			return 0
+		}
+		for augment(fn.source, math.Inf(1)) > flowEpsilon {
+			// Keep pushing until the level graph admits no more flow
+		}
+	}
+}
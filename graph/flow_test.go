@@ -0,0 +1,112 @@
+package graph
+
+import "testing"
+
+var maxFlowTests = []struct {
+	nodes  []int
+	edges  [][]weightedEdge
+	source int
+	sink   int
+	value  float64
+	cut    []int
+}{
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 16}, {2, 13}},
+			[]weightedEdge{{2, 10}, {3, 12}},
+			[]weightedEdge{{1, 4}, {4, 14}},
+			[]weightedEdge{{2, 9}, {5, 20}},
+			[]weightedEdge{{3, 7}, {5, 4}},
+			[]weightedEdge{},
+		},
+		0,
+		5,
+		23,
+		[]int{0, 1, 2, 4},
+	},
+	{
+		[]int{0, 1, 2, 3},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1000}, {2, 1000}},
+			[]weightedEdge{{2, 1}, {3, 1000}},
+			[]weightedEdge{{3, 1000}},
+			[]weightedEdge{},
+		},
+		0,
+		3,
+		2000,
+		[]int{0},
+	},
+	{
+		[]int{0, 1, 2},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 5}},
+			[]weightedEdge{},
+			[]weightedEdge{{1, 5}},
+		},
+		0,
+		2,
+		0,
+		[]int{0, 1},
+	},
+}
+
+func TestMaxFlow(t *testing.T) {
+	for _, tt := range maxFlowTests {
+		g, nodes := newWeightedIntGraph(tt.nodes, tt.edges)
+		for name, maxFlow := range map[string]func(Node, Node) (*Flow, error){
+			"EdmondsKarp": g.EdmondsKarp,
+			"Dinic":       g.Dinic,
+		} {
+			f, err := maxFlow(nodes[tt.source], nodes[tt.sink])
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if f.Value != tt.value {
+				t.Errorf("%s: expected flow %v, actual %v", name, tt.value, f.Value)
+			}
+			// Flow must respect capacities and be conserved at inner nodes
+			net := map[Node]float64{}
+			for _, ef := range f.Edges {
+				if ef.Flow < 0 || ef.Flow > ef.Edge.Weight {
+					t.Errorf("%s: flow %v exceeds capacity of %v->%v", name, ef.Flow, ef.Edge.From, ef.Edge.To)
+				}
+				net[ef.Edge.From] -= ef.Flow
+				net[ef.Edge.To] += ef.Flow
+			}
+			for _, n := range nodes {
+				if n != nodes[tt.source] && n != nodes[tt.sink] && net[n] != 0 {
+					t.Errorf("%s: flow not conserved at %v", name, n)
+				}
+			}
+			cut := map[Node]bool{}
+			for _, n := range f.SourceSide {
+				cut[n] = true
+			}
+			var capacity float64
+			for _, ef := range f.Edges {
+				if cut[ef.Edge.From] && !cut[ef.Edge.To] {
+					capacity += ef.Edge.Weight
+				}
+			}
+			if act := componentValues([][]Node{f.SourceSide})[0]; !equalInts(act, tt.cut) || capacity != tt.value {
+				t.Errorf("%s: expected cut %v, actual %v with capacity %v", name, tt.cut, act, capacity)
+			}
+		}
+	}
+}
+
+func TestMaxFlowErrors(t *testing.T) {
+	g, nodes := newWeightedIntGraph([]int{0, 1}, [][]weightedEdge{{{1, -1}}, {}})
+	if _, err := g.Dinic(nodes[0], nodes[1]); err == nil {
+		t.Errorf("Dinic: expected error for negative capacity, actual nil")
+	}
+	if _, err := g.EdmondsKarp(nodes[0], nodes[0]); err == nil {
+		t.Errorf("EdmondsKarp: expected error for source == sink, actual nil")
+	}
+	if _, err := g.EdmondsKarp(nodes[0], NewIntNode(2)); err == nil {
+		t.Errorf("EdmondsKarp: expected MissingNodeError, actual nil")
+	}
+}
@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// The generators below build indexed IntGraphs of n IntNodes with values 0 to
+// n-1, so that Nodes can be looked up with Get. Random generators take a seed,
+// and always build the same graph from the same arguments.
+
+// generated returns an indexed IntGraph of n IntNodes with values 0 to n-1
+func generated(n int, undirected bool) (*IntGraph, []*IntNode) {
+	g := NewIndexedIntGraph()
+	g.undirected = undirected
+	nodes := make([]*IntNode, n)
+	for i := range nodes {
+		nodes[i] = NewIntNode(i)
+		g.Insert(nodes[i])
+	}
+	return g, nodes
+}
+
+// sample calls f with each of the indexes 0 to m-1 independently with
+// probability p, in increasing order. Rather than rolling for every index, it
+// skips ahead by geometrically distributed gaps, so that sparse graphs take
+// time proportional to the number of edges chosen.
+func sample(r *rand.Rand, m int, p float64, f func(k int)) {
+	if p <= 0 {
+		return
+	}
+	if p >= 1 {
+		for k := 0; k < m; k++ {
+			f(k)
+		}
+		return
+	}
+	logq := math.Log(1 - p)
+	for k := -1; ; {
+		skip := math.Floor(math.Log(1-r.Float64()) / logq)
+		if skip >= float64(m-k-1) {
+			return
+		}
+		k += 1 + int(skip)
+		f(k)
+	}
+}
+
+// samplePairs calls f with each pair i < j of n Nodes independently with
+// probability p, in lexicographic order.
+func samplePairs(r *rand.Rand, n int, p float64, f func(i, j int)) {
+	i, start := 0, 0
+	sample(r, n*(n-1)/2, p, func(k int) {
+		// Row i holds the pairs (i, i+1) to (i, n-1)
+		for k >= start+n-1-i {
+			start += n - 1 - i
+			i++
+		}
+		f(i, i+1+k-start)
+	})
+}
+
+// ErdosRenyi returns a G(n, p) random graph: each of the possible edges among
+// n Nodes is present independently with probability p. A directed graph
+// considers both directions of each pair; neither has self-loops.
+func ErdosRenyi(n int, p float64, undirected bool, seed int64) *IntGraph {
+	r := rand.New(rand.NewSource(seed))
+	g, nodes := generated(n, undirected)
+	if undirected {
+		samplePairs(r, n, p, func(i, j int) {
+			g.AddNeighbor(nodes[i], nodes[j])
+		})
+		return g
+	}
+	sample(r, n*(n-1), p, func(k int) {
+		i, j := k/(n-1), k%(n-1)
+		if j >= i {
+			j++
+		}
+		nodes[i].AddNeighbor(nodes[j])
+	})
+	return g
+}
+
+// BarabasiAlbert returns an undirected scale-free random graph, grown by
+// preferential attachment. It starts from a complete graph of m+1 Nodes, then
+// adds each remaining Node with edges to m distinct existing Nodes, chosen
+// with probability proportional to their degree.
+func BarabasiAlbert(n, m int, seed int64) (*IntGraph, error) {
+	if m < 1 || m >= n {
+		return nil, fmt.Errorf("BarabasiAlbert requires 1 <= m < n, actual m=%d, n=%d", m, n)
+	}
+	r := rand.New(rand.NewSource(seed))
+	g, nodes := generated(n, true)
+	// Each Node appears in ends once per incident edge, so that a uniform
+	// choice from ends is a choice weighted by degree.
+	ends := make([]int, 0, 2*m*n)
+	for i := 0; i <= m; i++ {
+		for j := i + 1; j <= m; j++ {
+			g.AddNeighbor(nodes[i], nodes[j])
+			ends = append(ends, i, j)
+		}
+	}
+	for i := m + 1; i < n; i++ {
+		chosen := make(map[int]struct{}, m)
+		targets := make([]int, 0, m)
+		for len(targets) < m {
+			j := ends[r.Intn(len(ends))]
+			if _, ok := chosen[j]; !ok {
+				chosen[j] = struct{}{}
+				targets = append(targets, j)
+			}
+		}
+		// Targets are attached in ascending order, however they were drawn
+		sort.Ints(targets)
+		for _, j := range targets {
+			g.AddNeighbor(nodes[i], nodes[j])
+			ends = append(ends, i, j)
+		}
+	}
+	return g, nil
+}
+
+// Grid returns an undirected rows-by-cols grid graph. The Node in row y and
+// column x has value y*cols+x, and is connected to the Nodes above, below, left
+// and right of it.
+func Grid(rows, cols int) *IntGraph {
+	g, nodes := generated(rows*cols, true)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			i := y*cols + x
+			if x+1 < cols {
+				g.AddNeighbor(nodes[i], nodes[i+1])
+			}
+			if y+1 < rows {
+				g.AddNeighbor(nodes[i], nodes[i+cols])
+			}
+		}
+	}
+	return g
+}
+
+// Complete returns the complete graph of n Nodes, with an edge between every
+// pair of distinct Nodes, in both directions if the graph is directed.
+func Complete(n int, undirected bool) *IntGraph {
+	g, nodes := generated(n, undirected)
+	for i := range nodes {
+		for j := range nodes {
+			if i != j && (!undirected || i < j) {
+				g.AddNeighbor(nodes[i], nodes[j])
+			}
+		}
+	}
+	return g
+}
+
+// RandomTree returns an undirected tree of n Nodes, chosen uniformly from all
+// labeled trees by decoding a random Prüfer sequence.
+func RandomTree(n int, seed int64) *IntGraph {
+	r := rand.New(rand.NewSource(seed))
+	g, nodes := generated(n, true)
+	if n < 2 {
+		return g
+	}
+	code := make([]int, n-2)
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for i := range code {
+		code[i] = r.Intn(n)
+		degree[code[i]]++
+	}
+	// leaf is always the lowest-numbered leaf left; ptr is the lowest
+	// leaf found by scanning forward, which never has to move back.
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+	for _, v := range code {
+		g.AddNeighbor(nodes[leaf], nodes[v])
+		degree[v]--
+		if degree[v] == 1 && v < ptr {
+			leaf = v
+			continue
+		}
+		ptr++
+		for degree[ptr] != 1 {
+			ptr++
+		}
+		leaf = ptr
+	}
+	g.AddNeighbor(nodes[leaf], nodes[n-1])
+	return g
+}
+
+// RandomDAG returns a random directed acyclic graph of n Nodes. Each edge from
+// a lower value to a higher value is present independently with probability
+// p, so the values themselves are a topological order.
+func RandomDAG(n int, p float64, seed int64) *IntGraph {
+	r := rand.New(rand.NewSource(seed))
+	g, nodes := generated(n, false)
+	samplePairs(r, n, p, func(i, j int) {
+		nodes[i].AddNeighbor(nodes[j])
+	})
+	return g
+}
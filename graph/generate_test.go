@@ -0,0 +1,206 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// edgeCount returns the number of edges in g, counting each undirected edge
+// once.
+func edgeCount(g *IntGraph) int {
+	count := 0
+	for _, n := range g.Nodes() {
+		out, _ := g.OutDegree(n)
+		count += out
+	}
+	if g.Undirected() {
+		count /= 2
+	}
+	return count
+}
+
+// sameEdges returns true if a and b have Nodes with the same values, connected
+// in the same way.
+func sameEdges(a, b *IntGraph) bool {
+	var ab, bb strings.Builder
+	a.OrderBy(ValueLess)
+	b.OrderBy(ValueLess)
+	a.WriteEdgeList(&ab)
+	b.WriteEdgeList(&bb)
+	return ab.String() == bb.String()
+}
+
+var generateTests = []struct {
+	name       string
+	g          *IntGraph
+	undirected bool
+	size       int
+	edges      int
+}{
+	{"ErdosRenyi(p=0)", ErdosRenyi(10, 0, true, 1), true, 10, 0},
+	{"ErdosRenyi(p=1)", ErdosRenyi(10, 1, true, 1), true, 10, 45},
+	{"ErdosRenyi(directed, p=1)", ErdosRenyi(10, 1, false, 1), false, 10, 90},
+	{"Complete", Complete(6, true), true, 6, 15},
+	{"Complete(directed)", Complete(6, false), false, 6, 30},
+	{"Grid", Grid(3, 4), true, 12, 17},
+	{"Grid(1x1)", Grid(1, 1), true, 1, 0},
+	{"RandomTree", RandomTree(50, 1), true, 50, 49},
+	{"RandomTree(n=2)", RandomTree(2, 1), true, 2, 1},
+	{"RandomTree(n=0)", RandomTree(0, 1), true, 0, 0},
+	{"RandomDAG(p=1)", RandomDAG(8, 1, 1), false, 8, 28},
+}
+
+func TestGenerate(t *testing.T) {
+	for _, tt := range generateTests {
+		if tt.g.Size() != tt.size || tt.g.Undirected() != tt.undirected {
+			t.Errorf("%v: expected %v nodes (undirected %v), actual %v (undirected %v)", tt.name, tt.size, tt.undirected, tt.g.Size(), tt.g.Undirected())
+		}
+		if act := edgeCount(tt.g); act != tt.edges {
+			t.Errorf("%v: expected %v edges, actual %v", tt.name, tt.edges, act)
+		}
+		for i := 0; i < tt.size; i++ {
+			if _, ok := tt.g.Get(i); !ok {
+				t.Errorf("%v: expected Node with ID %v", tt.name, i)
+			}
+		}
+	}
+}
+
+func TestErdosRenyi(t *testing.T) {
+	// G(300, 0.05) has 2242.5 edges on average, with a standard deviation
+	// of about 46
+	for seed := int64(0); seed < 5; seed++ {
+		g := ErdosRenyi(300, 0.05, true, seed)
+		if act := edgeCount(g); act < 2000 || act > 2500 {
+			t.Errorf("ErdosRenyi: expected about 2242 edges, actual %v", act)
+		}
+		if !sameEdges(g, ErdosRenyi(300, 0.05, true, seed)) {
+			t.Errorf("ErdosRenyi: expected the same graph from seed %v", seed)
+		}
+	}
+	if sameEdges(ErdosRenyi(50, 0.2, false, 1), ErdosRenyi(50, 0.2, false, 2)) {
+		t.Errorf("ErdosRenyi: expected different graphs from different seeds")
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	g, err := BarabasiAlbert(500, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 6 edges among the first 4 Nodes, then 3 for each of the other 496
+	if act := edgeCount(g); act != 6+496*3 {
+		t.Errorf("BarabasiAlbert: expected %v edges, actual %v", 6+496*3, act)
+	}
+	c, _ := g.Connectivity()
+	if c.ComponentCount() != 1 {
+		t.Errorf("BarabasiAlbert: expected 1 component, actual %v", c.ComponentCount())
+	}
+	// Preferential attachment makes the earliest Nodes hubs
+	first, _ := g.Get(0)
+	last, _ := g.Get(499)
+	if df, _ := g.OutDegree(first); df < 20 {
+		t.Errorf("BarabasiAlbert: expected a hub at 0, actual degree %v", df)
+	}
+	if dl, _ := g.OutDegree(last); dl != 3 {
+		t.Errorf("BarabasiAlbert: expected degree 3 at 499, actual %v", dl)
+	}
+	for _, m := range []int{0, 500} {
+		if _, err := BarabasiAlbert(500, m, 1); err == nil {
+			t.Errorf("BarabasiAlbert(m=%v): expected error, actual nil", m)
+		}
+	}
+}
+
+func TestRandomTree(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		g := RandomTree(30, seed)
+		c, _ := g.Connectivity()
+		if edgeCount(g) != 29 || c.ComponentCount() != 1 {
+			t.Errorf("RandomTree: expected a spanning tree, actual %v edges in %v components", edgeCount(g), c.ComponentCount())
+		}
+	}
+}
+
+// FuzzTopologicalSort checks both topological sorts against random DAGs, in
+// which every edge runs from a lower value to a higher one.
+func FuzzTopologicalSort(f *testing.F) {
+	f.Add(int64(1), uint8(20), uint8(30))
+	f.Add(int64(7), uint8(60), uint8(5))
+	f.Fuzz(func(t *testing.T, seed int64, n uint8, percent uint8) {
+		g := RandomDAG(int(n), float64(percent%101)/100, seed)
+		for name, sort := range map[string]func() ([]Node, error){
+			"TopologicalSort":    g.TopologicalSort,
+			"TopologicalSortDFS": g.TopologicalSortDFS,
+		} {
+			order, err := sort()
+			if err != nil || len(order) != g.Size() {
+				t.Fatalf("%v: expected %v nodes, actual %v (%v)", name, g.Size(), len(order), err)
+			}
+			position := map[Node]int{}
+			for i, node := range order {
+				position[node] = i
+			}
+			for _, node := range order {
+				for nbr := range node.Neighbors() {
+					if position[nbr] < position[node] {
+						t.Fatalf("%v: expected %v before %v", name, node, nbr)
+					}
+				}
+			}
+		}
+	})
+}
+
+// FuzzConnectivity checks the union-find Connectivity index against BFS on
+// random undirected graphs.
+func FuzzConnectivity(f *testing.F) {
+	f.Add(int64(1), uint8(40), uint8(3))
+	f.Add(int64(2), uint8(100), uint8(1))
+	f.Fuzz(func(t *testing.T, seed int64, n uint8, percent uint8) {
+		g := ErdosRenyi(int(n), float64(percent%101)/100, true, seed)
+		c, err := g.Connectivity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes := g.Nodes()
+		for i := 0; i+1 < len(nodes); i += 7 {
+			a, b := nodes[i], nodes[len(nodes)-1-i]
+			if c.RouteExists(a, b) != g.RouteExists(a, b) {
+				t.Fatalf("Connectivity: expected RouteExists(%v, %v) = %v", a, b, g.RouteExists(a, b))
+			}
+		}
+	})
+}
+
+func BenchmarkShortestPath(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		g, _ := BarabasiAlbert(n, 4, 1)
+		start, _ := g.Get(n - 1)
+		finish, _ := g.Get(n / 2)
+		b.Run(fmt.Sprintf("BarabasiAlbert(%d)", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.ShortestPath(start, finish)
+			}
+		})
+	}
+}
+
+func BenchmarkStronglyConnectedComponents(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		g := ErdosRenyi(n, 2/float64(n), false, 1)
+		b.Run(fmt.Sprintf("ErdosRenyi(%d)", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.StronglyConnectedComponents()
+			}
+		})
+	}
+}
+
+func BenchmarkTopologicalSort(b *testing.B) {
+	g := RandomDAG(10000, 0.001, 1)
+	for i := 0; i < b.N; i++ {
+		g.TopologicalSort()
+	}
+}
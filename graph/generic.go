@@ -0,0 +1,220 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Graph implements a Graph of Vertices, each identified by a comparable key of
+// type K and holding a value of type V. It mirrors IntGraph, but without
+// forcing callers through interface{} type assertions.
+type Graph[K comparable, V any] struct {
+	lock  sync.Mutex
+	nodes map[K]*Vertex[K, V]
+}
+
+// Vertex is the Node of a Graph[K, V], with a key, a value and a set of
+// neighbors.
+type Vertex[K comparable, V any] struct {
+	lock      sync.Mutex
+	key       K
+	value     V
+	neighbors map[*Vertex[K, V]]struct{}
+}
+
+// VertexSearchFunc is applied to each Vertex that a Graph search algorithm
+// visits. As with SearchFunc, it has the option to return a value, as well as
+// to tell the search routine to stop by returning done=true.
+type VertexSearchFunc[K comparable, V any] func(*Vertex[K, V]) (value interface{}, done bool)
+
+// MissingVertexError describes the case when a Graph does not contain a Vertex
+// that has been referenced.
+type MissingVertexError[K comparable] struct {
+	key K
+}
+
+func (err MissingVertexError[K]) Error() string {
+	return fmt.Sprintf("Graph does not contain Vertex\nkey: %v", err.key)
+}
+
+// NewVertex creates and returns a new *Vertex
+func NewVertex[K comparable, V any](key K, value V) *Vertex[K, V] {
+	return &Vertex[K, V]{
+		key:       key,
+		value:     value,
+		neighbors: map[*Vertex[K, V]]struct{}{},
+	}
+}
+
+// String returns a string representation of a Vertex
+func (v *Vertex[K, V]) String() string {
+	return fmt.Sprintf("%v(%v)", v.key, v.value)
+}
+
+// Key returns the key of a Vertex
+func (v *Vertex[K, V]) Key() K {
+	return v.key
+}
+
+// Value returns the value of a Vertex
+func (v *Vertex[K, V]) Value() V {
+	return v.value
+}
+
+// Neighbors returns the set of v's neighboring Vertices
+func (v *Vertex[K, V]) Neighbors() map[*Vertex[K, V]]struct{} {
+	return v.neighbors
+}
+
+// AddNeighbor adds an edge from v to vertex
+func (v *Vertex[K, V]) AddNeighbor(vertex *Vertex[K, V]) error {
+	if v.HasNeighbor(vertex) {
+		return nil
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.neighbors[vertex] = struct{}{}
+	return nil
+}
+
+// RemoveNeighbor removes an edge from v to vertex, if it exists
+func (v *Vertex[K, V]) RemoveNeighbor(vertex *Vertex[K, V]) error {
+	if !v.HasNeighbor(vertex) {
+		return nil
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	delete(v.neighbors, vertex)
+	return nil
+}
+
+// HasNeighbor returns true if vertex is v's neighbor
+func (v *Vertex[K, V]) HasNeighbor(vertex *Vertex[K, V]) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	_, ok := v.neighbors[vertex]
+	return ok
+}
+
+// NewGraph creates and returns a new *Graph
+func NewGraph[K comparable, V any]() *Graph[K, V] {
+	return &Graph[K, V]{nodes: map[K]*Vertex[K, V]{}}
+}
+
+// String returns a string representation of the graph as an adjacency list.
+func (g *Graph[K, V]) String() string {
+	var str string
+	for _, vertex := range g.nodes {
+		str += fmt.Sprintf("%v", vertex) + "->{ "
+		for n := range vertex.Neighbors() {
+			str += fmt.Sprintf("%v", n) + " "
+		}
+		str += "}\n"
+	}
+	return str
+}
+
+// Size returns the number of Vertices in the Graph
+func (g *Graph[K, V]) Size() int {
+	return len(g.nodes)
+}
+
+// Get returns the Vertex with the given key, if the Graph contains one
+func (g *Graph[K, V]) Get(key K) (*Vertex[K, V], bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	vertex, ok := g.nodes[key]
+	return vertex, ok
+}
+
+// HasNode returns true if the Graph has the vertex
+func (g *Graph[K, V]) HasNode(vertex *Vertex[K, V]) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	v, ok := g.nodes[vertex.key]
+	return ok && v == vertex
+}
+
+// Insert adds vertex to the graph. If the graph already holds a Vertex with
+// the same key, it is left in place.
+func (g *Graph[K, V]) Insert(vertex *Vertex[K, V]) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.nodes[vertex.key]; ok {
+		return
+	}
+	g.nodes[vertex.key] = vertex
+}
+
+// Remove removes vertex from the graph
+func (g *Graph[K, V]) Remove(vertex *Vertex[K, V]) {
+	if !g.HasNode(vertex) {
+		return
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.nodes, vertex.key)
+}
+
+// DFS executes a depth-first search, applying the VertexSearchFunc to each
+// Vertex visited to yield values and determine whether or not to continue.
+func (g *Graph[K, V]) DFS(vertex *Vertex[K, V], sf VertexSearchFunc[K, V]) (interface{}, error) {
+	if !g.HasNode(vertex) {
+		return nil, MissingVertexError[K]{vertex.key}
+	}
+	visited := map[*Vertex[K, V]]struct{}{}
+	if value, done := g.dfs(vertex, sf, visited); done {
+		return value, nil
+	}
+	return nil, NotFoundError{"Search exhausted graph: objective not found"}
+}
+
+func (g *Graph[K, V]) dfs(vertex *Vertex[K, V], sf VertexSearchFunc[K, V], visited map[*Vertex[K, V]]struct{}) (interface{}, bool) {
+	if value, done := sf(vertex); done {
+		return value, true
+	}
+	visited[vertex] = struct{}{}
+	for nbr := range vertex.Neighbors() {
+		if _, ok := visited[nbr]; !ok {
+			if value, done := g.dfs(nbr, sf, visited); done {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// BFS executes a breadth-first search, applying the VertexSearchFunc to each
+// Vertex visited to yield values and determine whether or not to continue.
+func (g *Graph[K, V]) BFS(vertex *Vertex[K, V], sf VertexSearchFunc[K, V]) (interface{}, error) {
+	if !g.HasNode(vertex) {
+		return nil, MissingVertexError[K]{vertex.key}
+	}
+	visited := map[*Vertex[K, V]]struct{}{vertex: {}}
+	queue := []*Vertex[K, V]{vertex}
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		if value, done := sf(curr); done {
+			return value, nil
+		}
+		for n := range curr.Neighbors() {
+			if _, ok := visited[n]; !ok {
+				visited[n] = struct{}{}
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil, NotFoundError{"Search exhausted graph: objective not found"}
+}
+
+// RouteExists returns true if a route from start to finish exists
+func (g *Graph[K, V]) RouteExists(start, finish *Vertex[K, V]) bool {
+	if !g.HasNode(start) || !g.HasNode(finish) {
+		return false
+	}
+	_, err := g.BFS(start, func(vertex *Vertex[K, V]) (interface{}, bool) {
+		return nil, vertex == finish
+	})
+	return err == nil
+}
@@ -0,0 +1,103 @@
+package graph
+
+import "testing"
+
+type service struct {
+	name string
+	port int
+}
+
+var genericRouteExistsTests = []struct {
+	keys   []string
+	edges  map[string][]string
+	start  string
+	finish string
+	exp    bool
+}{
+	{
+		[]string{"api", "auth", "db"},
+		map[string][]string{
+			"api":  []string{"auth", "db"},
+			"auth": []string{"db"},
+		},
+		"api",
+		"db",
+		true,
+	},
+	{
+		[]string{"api", "auth", "db", "cache"},
+		map[string][]string{
+			"api":  []string{"auth"},
+			"auth": []string{"db"},
+			"db":   []string{"auth"},
+		},
+		"api",
+		"cache",
+		false,
+	},
+	{
+		[]string{"api", "auth", "db", "cache"},
+		map[string][]string{
+			"api":   []string{"auth"},
+			"auth":  []string{"db"},
+			"db":    []string{"cache"},
+			"cache": []string{"api"},
+		},
+		"db",
+		"auth",
+		true,
+	},
+}
+
+func TestGenericRouteExists(t *testing.T) {
+	for _, tt := range genericRouteExistsTests {
+		g := NewGraph[string, service]()
+		for i, k := range tt.keys {
+			g.Insert(NewVertex(k, service{k, 8000 + i}))
+		}
+		for from, tos := range tt.edges {
+			f, _ := g.Get(from)
+			for _, to := range tos {
+				v, _ := g.Get(to)
+				f.AddNeighbor(v)
+			}
+		}
+		start, _ := g.Get(tt.start)
+		finish, _ := g.Get(tt.finish)
+		if act := g.RouteExists(start, finish); act != tt.exp {
+			t.Errorf("RouteExists: expected %v, actual %v", tt.exp, act)
+		}
+	}
+}
+
+func TestGenericSearch(t *testing.T) {
+	g := NewGraph[string, service]()
+	for i, k := range []string{"api", "auth", "db", "cache"} {
+		g.Insert(NewVertex(k, service{k, 8000 + i}))
+	}
+	api, _ := g.Get("api")
+	auth, _ := g.Get("auth")
+	db, _ := g.Get("db")
+	api.AddNeighbor(auth)
+	auth.AddNeighbor(db)
+	findPort := func(port int) VertexSearchFunc[string, service] {
+		return func(v *Vertex[string, service]) (interface{}, bool) {
+			if v.Value().port == port {
+				return v.Key(), true
+			}
+			return nil, false
+		}
+	}
+	for _, search := range []func(*Vertex[string, service], VertexSearchFunc[string, service]) (interface{}, error){g.DFS, g.BFS} {
+		value, err := search(api, findPort(8002))
+		if err != nil || value != "db" {
+			t.Errorf("Search: expected db, actual %v (%v)", value, err)
+		}
+		if _, err := search(api, findPort(8003)); err == nil {
+			t.Errorf("Search: expected NotFoundError, actual nil")
+		}
+		if _, err := search(NewVertex("db", service{}), findPort(8002)); err == nil {
+			t.Errorf("Search: expected MissingVertexError, actual nil")
+		}
+	}
+}
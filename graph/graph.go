@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -22,22 +23,99 @@ type Node interface {
 	RemoveNeighbor(Node) error
 }
 
+// WeightedNode is a Node whose edges carry a weight and arbitrary metadata.
+// Edges added through AddNeighbor have a weight of 1.
+type WeightedNode interface {
+	Node
+	AddEdge(node Node, weight float64, meta interface{}) error
+	Edge(Node) (Edge, bool)
+}
+
+// ReverseNode is a Node that also tracks its incoming edges, i.e. the set of
+// Nodes that have it as a neighbor.
+type ReverseNode interface {
+	Node
+	Predecessors() map[Node]struct{}
+}
+
+// OrderedNode is a Node that keeps its neighbors in a stable order, e.g. the
+// order in which they were added.
+type OrderedNode interface {
+	Node
+	OrderedNeighbors() []Node
+}
+
+// SnapshotNode is a read-only Node of a snapshot, standing for the Node of the
+// live graph that it was frozen from.
+type SnapshotNode interface {
+	WeightedNode
+	OrderedNode
+	Source() Node
+}
+
+// freezer is implemented by Nodes that can hand their adjacency to a snapshot
+// without copying it, by copying it on their next change instead.
+type freezer interface {
+	freeze() (order []Node, edges map[Node]Edge, preds map[Node]struct{})
+}
+
+// predecessorTracker is implemented by Nodes that want to be notified when an
+// edge to them is added or removed.
+type predecessorTracker interface {
+	addPredecessor(Node)
+	removePredecessor(Node)
+}
+
+// Edge describes a directed, weighted edge between two Nodes. Meta holds any
+// caller-defined data attached to the edge, e.g. a label or link latency.
+type Edge struct {
+	From   Node
+	To     Node
+	Weight float64
+	Meta   interface{}
+}
+
 // SearchFunc is applied to each Node that a graph search algorithm visits.
 // It has the option to return a value, as well as to tell the search routine
 // to stop by returning done=true.
 type SearchFunc func(Node) (value interface{}, done bool)
 
-// IntGraph implements a Graph of IntNodes
+// IntGraph implements a Graph of IntNodes. An undirected IntGraph keeps its
+// edges symmetric when they are added through the IntGraph. An indexed
+// IntGraph identifies each Node by its int value, holding at most one Node per
+// value, so that Nodes can be looked up and connected by ID.
+//
+// A snapshot IntGraph, as returned by Snapshot, is read-only: it holds
+// SnapshotNodes, and frozen maps each Node of the live graph to its own.
+// Snapshots are only guaranteed consistent with edits made through the
+// IntGraph's own methods, e.g. AddNeighbor and Remove; see Snapshot.
 type IntGraph struct {
-	lock  sync.Mutex
-	nodes map[Node]struct{}
-}
-
-// IntNode implements Node for int values
+	lock       sync.Mutex
+	edit       sync.RWMutex
+	nodes      map[Node]int
+	seq        int
+	index      map[int]Node
+	undirected bool
+	ordered    bool
+	less       func(a, b Node) bool
+	frozen     map[Node]*frozenNode
+}
+
+// IntNode implements Node for int values. It is an OrderedNode, keeping its
+// neighbors in the order they were added. Once shared with a snapshot, its
+// order, edges and predecessors are copied before they are next changed.
+// Calling AddNeighbor, AddEdge or RemoveNeighbor on an IntNode directly does
+// not coordinate with IntGraph.Snapshot, so a snapshot taken meanwhile may see
+// the edge on one side only; edit through the IntGraph where that matters.
 type IntNode struct {
-	lock      sync.Mutex
-	value     int
-	neighbors map[Node]struct{}
+	lock         sync.Mutex
+	value        int
+	neighbors    map[Node]struct{}
+	order        []Node
+	position     map[Node]int
+	edges        map[Node]Edge
+	predecessors map[Node]struct{}
+	shared       bool
 }
 
 // BST defines the behavior of a binary search tree data structure
@@ -90,6 +168,86 @@ func (err MissingNodeError) Error() string {
 	return fmt.Sprintf("Graph does not contain Node\ngraph: %v\nnode: %v", err.graph, err.node)
 }
 
+// CycleError describes the case when an operation requires an acyclic Graph,
+// but the Graph contains a cycle. Cycle lists the Nodes of the cycle in order,
+// starting and ending at the same Node.
+type CycleError struct {
+	Cycle []Node
+}
+
+func (err CycleError) Error() string {
+	return fmt.Sprintf("Graph contains a cycle: %v", err.Cycle)
+}
+
+// OddCycleError describes the case when an operation requires a bipartite
+// Graph, but the Graph contains a cycle of odd length, which cannot be
+// two-colored. Cycle lists the Nodes of the cycle in order, starting and ending
+// at the same Node.
+type OddCycleError struct {
+	Cycle []Node
+}
+
+func (err OddCycleError) Error() string {
+	return fmt.Sprintf("Graph is not bipartite: odd cycle %v", err.Cycle)
+}
+
+// NegativeCycleError describes the case when a Graph contains a cycle of
+// negative total weight, so that shortest paths through it are undefined. Cycle
+// lists the Nodes of the cycle in order, starting and ending at the same Node.
+type NegativeCycleError struct {
+	Cycle []Node
+}
+
+func (err NegativeCycleError) Error() string {
+	return fmt.Sprintf("Graph contains a negative-weight cycle: %v", err.Cycle)
+}
+
+// DegreeError describes the case when the degrees of a Graph's Nodes rule out
+// what was asked of it, e.g. an Eulerian circuit. Nodes lists the Nodes whose
+// degrees break the requirement.
+type DegreeError struct {
+	msg   string
+	Nodes []Node
+}
+
+func (err DegreeError) Error() string {
+	return fmt.Sprintf("%s: %v", err.msg, err.Nodes)
+}
+
+// ColoringError describes the case when a coloring of a Graph is not proper.
+// Conflicts lists the edges whose ends share a color, and Uncolored the Nodes
+// that were given no color at all.
+type ColoringError struct {
+	Conflicts []Edge
+	Uncolored []Node
+}
+
+func (err ColoringError) Error() string {
+	return fmt.Sprintf("Coloring is not proper: %d conflicting edges, %d uncolored Nodes", len(err.Conflicts), len(err.Uncolored))
+}
+
+// ReadOnlyError describes an attempt to change a snapshot or one of its Nodes
+type ReadOnlyError struct {
+	node Node
+}
+
+func (err ReadOnlyError) Error() string {
+	if err.node == nil {
+		return "Snapshot is read-only"
+	}
+	return fmt.Sprintf("Snapshot is read-only\nnode: %v", err.node)
+}
+
+// MissingIDError describes the case when a Graph does not contain a Node with
+// the ID that has been referenced.
+type MissingIDError struct {
+	id int
+}
+
+func (err MissingIDError) Error() string {
+	return fmt.Sprintf("Graph does not contain Node with ID %v", err.id)
+}
+
 // NotFoundError describes the state when a Graph search completes without
 // completing the objective, which is indicated by the SearchFunc returning
 // done=true.
@@ -104,8 +262,11 @@ func (err NotFoundError) Error() string {
 // NewIntNode creates and returns a new *IntNode
 func NewIntNode(value int) *IntNode {
 	return &IntNode{
-		value:     value,
-		neighbors: map[Node]struct{}{},
+		value:        value,
+		neighbors:    map[Node]struct{}{},
+		position:     map[Node]int{},
+		edges:        map[Node]Edge{},
+		predecessors: map[Node]struct{}{},
 	}
 }
 
@@ -129,23 +290,146 @@ func (n *IntNode) AddNeighbor(node Node) error {
 	if n.HasNeighbor(node) {
 		return nil
 	}
+	return n.AddEdge(node, 1, nil)
+}
+
+// AddEdge adds an edge from n to node with the given weight and metadata. If
+// the edge already exists, its weight and metadata are replaced.
+func (n *IntNode) AddEdge(node Node, weight float64, meta interface{}) error {
 	n.lock.Lock()
-	defer n.lock.Unlock()
+	n.unshare()
+	if _, ok := n.neighbors[node]; !ok {
+		n.position[node] = len(n.order)
+		n.order = append(n.order, node)
+	}
 	n.neighbors[node] = struct{}{}
+	n.edges[node] = Edge{From: n, To: node, Weight: weight, Meta: meta}
+	n.lock.Unlock()
+	if pt, ok := node.(predecessorTracker); ok {
+		pt.addPredecessor(n)
+	}
 	return nil
 }
 
-// RemoveNeighbor removes an edge from n to node, if it exists
+// Edge returns the edge from n to node, if it exists
+func (n *IntNode) Edge(node Node) (Edge, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	e, ok := n.edges[node]
+	return e, ok
+}
+
+// RemoveNeighbor removes an edge from n to node, if it exists. It takes
+// constant amortized time: the neighbor's place in the order is left empty,
+// and the order is compacted once half of it is empty.
 func (n *IntNode) RemoveNeighbor(node Node) error {
 	if !n.HasNeighbor(node) {
 		return nil
 	}
 	n.lock.Lock()
-	defer n.lock.Unlock()
+	n.unshare()
+	if i, ok := n.position[node]; ok {
+		n.order[i] = nil
+		delete(n.position, node)
+	}
 	delete(n.neighbors, node)
+	delete(n.edges, node)
+	if len(n.order) > 2*len(n.neighbors) {
+		n.compact()
+	}
+	n.lock.Unlock()
+	if pt, ok := node.(predecessorTracker); ok {
+		pt.removePredecessor(n)
+		// A concurrent AddEdge may have restored the edge meanwhile
+		if n.HasNeighbor(node) {
+			pt.addPredecessor(n)
+		}
+	}
 	return nil
 }
 
+// compact removes the empty places left in n's order by RemoveNeighbor. It
+// must be called with n.lock held, and n not shared.
+func (n *IntNode) compact() {
+	order := n.order[:0]
+	for _, nbr := range n.order {
+		if nbr != nil {
+			n.position[nbr] = len(order)
+			order = append(order, nbr)
+		}
+	}
+	clear(n.order[len(order):])
+	n.order = order
+}
+
+// unshare gives n its own copies of the adjacency it shares with a snapshot.
+// It must be called with n.lock held.
+func (n *IntNode) unshare() {
+	if !n.shared {
+		return
+	}
+	edges := make(map[Node]Edge, len(n.edges))
+	for nbr, e := range n.edges {
+		edges[nbr] = e
+	}
+	n.edges = edges
+	n.order = append([]Node{}, n.order...)
+	preds := make(map[Node]struct{}, len(n.predecessors))
+	for p := range n.predecessors {
+		preds[p] = struct{}{}
+	}
+	n.predecessors = preds
+	n.shared = false
+}
+
+// freeze shares n's adjacency with a snapshot. The order may hold empty places
+// left by RemoveNeighbor, which the snapshot skips.
+func (n *IntNode) freeze() ([]Node, map[Node]Edge, map[Node]struct{}) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.shared = true
+	return n.order, n.edges, n.predecessors
+}
+
+// OrderedNeighbors returns n's neighbors in the order they were added
+func (n *IntNode) OrderedNeighbors() []Node {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	order := make([]Node, 0, len(n.neighbors))
+	for _, nbr := range n.order {
+		if nbr != nil {
+			order = append(order, nbr)
+		}
+	}
+	return order
+}
+
+// Predecessors returns a copy of the set of IntNodes with an edge to n. Only
+// edges added by Nodes that notify n, such as IntNodes, are tracked.
+func (n *IntNode) Predecessors() map[Node]struct{} {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	preds := make(map[Node]struct{}, len(n.predecessors))
+	for p := range n.predecessors {
+		preds[p] = struct{}{}
+	}
+	return preds
+}
+
+func (n *IntNode) addPredecessor(node Node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.unshare()
+	n.predecessors[node] = struct{}{}
+}
+
+func (n *IntNode) removePredecessor(node Node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.unshare()
+	delete(n.predecessors, node)
+}
+
 // HasNeighbor returns true if node is n's neighbor
 func (n *IntNode) HasNeighbor(node Node) bool {
 	n.lock.Lock()
@@ -156,15 +440,186 @@ func (n *IntNode) HasNeighbor(node Node) bool {
 
 // NewIntGraph creates and returns a new *IntGraph
 func NewIntGraph() *IntGraph {
-	return &IntGraph{nodes: map[Node]struct{}{}}
+	return &IntGraph{nodes: map[Node]int{}}
+}
+
+// NewUndirectedIntGraph creates and returns a new, undirected *IntGraph
+func NewUndirectedIntGraph() *IntGraph {
+	return &IntGraph{nodes: map[Node]int{}, undirected: true}
+}
+
+// NewIndexedIntGraph creates and returns a new, indexed *IntGraph
+func NewIndexedIntGraph() *IntGraph {
+	return &IntGraph{nodes: map[Node]int{}, index: map[int]Node{}}
+}
+
+// NewIndexedUndirectedIntGraph creates and returns a new, indexed and
+// undirected *IntGraph
+func NewIndexedUndirectedIntGraph() *IntGraph {
+	return &IntGraph{nodes: map[Node]int{}, index: map[int]Node{}, undirected: true}
+}
+
+// Indexed returns true if the IntGraph identifies Nodes by their values
+func (g *IntGraph) Indexed() bool {
+	return g.index != nil
+}
+
+// Get returns the Node with the given ID, i.e. value. In an IntGraph that is
+// not indexed, this scans the graph and returns any Node with that value.
+func (g *IntGraph) Get(id int) (Node, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.get(id)
+}
+
+func (g *IntGraph) get(id int) (Node, bool) {
+	if g.index != nil {
+		node, ok := g.index[id]
+		return node, ok
+	}
+	for node := range g.nodes {
+		if v, ok := node.Value().(int); ok && v == id {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// GetOrCreate returns the Node with the given ID, first inserting a new
+// IntNode with that value if the graph has none. A snapshot cannot insert, so
+// it returns only the Nodes it holds, and nil for any other ID; use
+// GetOrInsert to have that reported as an error.
+func (g *IntGraph) GetOrCreate(id int) Node {
+	node, _ := g.GetOrInsert(id)
+	return node
+}
+
+// GetOrInsert returns the Node with the given ID, first inserting a new
+// IntNode with that value if the graph has none. A snapshot returns a
+// ReadOnlyError for an ID it does not hold.
+func (g *IntGraph) GetOrInsert(id int) (Node, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if node, ok := g.get(id); ok {
+		return node, nil
+	}
+	if g.frozen != nil {
+		return nil, ReadOnlyError{}
+	}
+	node := NewIntNode(id)
+	g.insert(node)
+	return node, nil
+}
+
+// AddNeighborByID adds an edge between the Nodes with the given IDs, as
+// AddNeighbor does.
+func (g *IntGraph) AddNeighborByID(from, to int) error {
+	f, t, err := g.getPair(from, to)
+	if err != nil {
+		return err
+	}
+	return g.AddNeighbor(f, t)
+}
+
+// AddEdgeByID adds a weighted edge between the Nodes with the given IDs, as
+// AddEdge does.
+func (g *IntGraph) AddEdgeByID(from, to int, weight float64, meta interface{}) error {
+	f, t, err := g.getPair(from, to)
+	if err != nil {
+		return err
+	}
+	fw, ok := f.(WeightedNode)
+	if !ok {
+		return fmt.Errorf("Node %v does not support weighted edges", f)
+	}
+	tw, ok := t.(WeightedNode)
+	if !ok {
+		return fmt.Errorf("Node %v does not support weighted edges", t)
+	}
+	return g.AddEdge(fw, tw, weight, meta)
+}
+
+// RemoveNeighborByID removes the edge between the Nodes with the given IDs, if
+// it exists. In an undirected IntGraph, the edge back is removed as well.
+func (g *IntGraph) RemoveNeighborByID(from, to int) error {
+	g.edit.RLock()
+	defer g.edit.RUnlock()
+	f, t, err := g.getPair(from, to)
+	if err != nil {
+		return err
+	}
+	if err := f.RemoveNeighbor(t); err != nil {
+		return err
+	}
+	if g.undirected {
+		return t.RemoveNeighbor(f)
+	}
+	return nil
+}
+
+func (g *IntGraph) getPair(from, to int) (Node, Node, error) {
+	f, ok := g.Get(from)
+	if !ok {
+		return nil, nil, MissingIDError{from}
+	}
+	t, ok := g.Get(to)
+	if !ok {
+		return nil, nil, MissingIDError{to}
+	}
+	return f, t, nil
+}
+
+// Undirected returns true if the IntGraph is undirected
+func (g *IntGraph) Undirected() bool {
+	return g.undirected
+}
+
+// AddNeighbor adds an edge from one Node in the graph to another. In an
+// undirected IntGraph, the edge back from to to from is added as well.
+func (g *IntGraph) AddNeighbor(from, to Node) error {
+	g.edit.RLock()
+	defer g.edit.RUnlock()
+	if !g.HasNode(from) {
+		return MissingNodeError{g, from}
+	}
+	if !g.HasNode(to) {
+		return MissingNodeError{g, to}
+	}
+	if err := from.AddNeighbor(to); err != nil {
+		return err
+	}
+	if g.undirected && from != to {
+		return to.AddNeighbor(from)
+	}
+	return nil
+}
+
+// AddEdge adds a weighted edge from one WeightedNode in the graph to another.
+// In an undirected IntGraph, the edge back from to to from is added as well.
+func (g *IntGraph) AddEdge(from, to WeightedNode, weight float64, meta interface{}) error {
+	g.edit.RLock()
+	defer g.edit.RUnlock()
+	if !g.HasNode(from) {
+		return MissingNodeError{g, from}
+	}
+	if !g.HasNode(to) {
+		return MissingNodeError{g, to}
+	}
+	if err := from.AddEdge(to, weight, meta); err != nil {
+		return err
+	}
+	if g.undirected && from != to {
+		return to.AddEdge(from, weight, meta)
+	}
+	return nil
 }
 
 // String returns a string representation of the graph as an adjecency list.
 func (g *IntGraph) String() string {
 	var str string
-	for node := range g.nodes {
+	for _, node := range g.nodeList() {
 		str += fmt.Sprintf("%v", node) + "->{ "
-		for n := range node.Neighbors() {
+		for _, n := range g.adjacent(node) {
 			str += fmt.Sprintf("%v", n) + " "
 		}
 		str += "}\n"
@@ -185,94 +640,225 @@ func (g *IntGraph) HasNode(node Node) bool {
 	return ok
 }
 
-// Insert adds node to the graph
-func (g *IntGraph) Insert(node Node) {
-	if g.HasNode(node) {
-		return
+// OrderByInsertion makes the IntGraph's algorithms visit Nodes in the order
+// they were inserted, and each OrderedNode's neighbors in the order they were
+// added, so that results are reproducible from run to run.
+func (g *IntGraph) OrderByInsertion() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.ordered, g.less = true, nil
+}
+
+// OrderBy makes the IntGraph's algorithms visit Nodes, and each Node's
+// neighbors, in the order given by less. Ties are broken by insertion order.
+func (g *IntGraph) OrderBy(less func(a, b Node) bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.ordered, g.less = true, less
+}
+
+// Unordered restores the default neighbor order of the IntGraph, which is
+// whatever order each Node's Neighbors map yields.
+func (g *IntGraph) Unordered() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.ordered, g.less = false, nil
+}
+
+func (g *IntGraph) ordering() (bool, func(a, b Node) bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.ordered, g.less
+}
+
+// nodeList returns the IntGraph's Nodes as a slice, in the IntGraph's order
+func (g *IntGraph) nodeList() []Node {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	nodes := make([]Node, 0, len(g.nodes))
+	for node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	if g.ordered {
+		sort.Slice(nodes, func(i, j int) bool {
+			if g.less != nil {
+				if g.less(nodes[i], nodes[j]) {
+					return true
+				}
+				if g.less(nodes[j], nodes[i]) {
+					return false
+				}
+			}
+			return g.nodes[nodes[i]] < g.nodes[nodes[j]]
+		})
 	}
+	return nodes
+}
+
+// Nodes returns the IntGraph's Nodes, in the IntGraph's order
+func (g *IntGraph) Nodes() []Node {
+	return g.nodeList()
+}
+
+// Adjacent returns node's neighbors in the graph, in the IntGraph's order
+func (g *IntGraph) Adjacent(node Node) ([]Node, error) {
+	if !g.HasNode(node) {
+		return nil, MissingNodeError{g, node}
+	}
+	nbrs := []Node{}
+	for _, nbr := range g.adjacent(node) {
+		if g.HasNode(nbr) {
+			nbrs = append(nbrs, nbr)
+		}
+	}
+	return nbrs, nil
+}
+
+// adjacent returns node's neighbors in the IntGraph's order. Every graph
+// algorithm visits neighbors through adjacent, so that all of them respect
+// OrderByInsertion and OrderBy.
+func (g *IntGraph) adjacent(node Node) []Node {
+	ordered, less := g.ordering()
+	if !ordered {
+		return neighborList(node)
+	}
+	var nbrs []Node
+	if on, ok := node.(OrderedNode); ok {
+		nbrs = on.OrderedNeighbors()
+	} else {
+		nbrs = neighborList(node)
+	}
+	if less != nil {
+		sort.SliceStable(nbrs, func(i, j int) bool { return less(nbrs[i], nbrs[j]) })
+	}
+	return nbrs
+}
+
+// Insert adds node to the graph. An indexed IntGraph ignores node if it
+// already holds a Node with the same value, and a snapshot ignores it always.
+func (g *IntGraph) Insert(node Node) {
 	g.lock.Lock()
 	defer g.lock.Unlock()
-	g.nodes[node] = struct{}{}
+	g.insert(node)
 }
 
-// Remove removes node from the graph
+func (g *IntGraph) insert(node Node) {
+	if _, ok := g.nodes[node]; ok || g.frozen != nil {
+		return
+	}
+	if g.index != nil {
+		if id, ok := node.Value().(int); ok {
+			if _, ok := g.index[id]; ok {
+				return
+			}
+			g.index[id] = node
+		}
+	}
+	g.nodes[node] = g.seq
+	g.seq++
+}
+
+// Remove removes node from the graph, detaching it by removing every edge to
+// and from it. For a ReverseNode such as an IntNode, whose edges are removed
+// in constant amortized time, this takes time proportional to its degree;
+// otherwise, every Node in the graph is checked for an edge to node. A
+// snapshot ignores Remove.
 func (g *IntGraph) Remove(node Node) {
-	if !g.HasNode(node) {
+	if g.frozen != nil || !g.HasNode(node) {
 		return
 	}
+	g.edit.RLock()
+	defer g.edit.RUnlock()
+	preds := g.predecessors(node)
 	g.lock.Lock()
-	defer g.lock.Unlock()
 	delete(g.nodes, node)
-	/* TODO Determine if losing this to the Interface change is problematic
-	for n := range g.nodes {
-		n.RemoveNeighbor(node)
+	if id, ok := node.Value().(int); ok && g.index != nil && g.index[id] == node {
+		delete(g.index, id)
+	}
+	g.lock.Unlock()
+	for _, p := range preds {
+		p.RemoveNeighbor(node)
+	}
+	for _, nbr := range neighborList(node) {
+		node.RemoveNeighbor(nbr)
 	}
-	*/
-	return
 }
 
-// DFS executes a depth-first search, applying the SearchFunc to each IntNode
-// visited to yield values and determine whether or not to continue.
-func (g *IntGraph) DFS(node Node, sf SearchFunc) (interface{}, error) {
+// neighborList returns node's neighbors as a slice, so that edges can be
+// removed while iterating.
+func neighborList(node Node) []Node {
+	nbrs := make([]Node, 0, len(node.Neighbors()))
+	for nbr := range node.Neighbors() {
+		nbrs = append(nbrs, nbr)
+	}
+	return nbrs
+}
+
+// predecessors returns the Nodes in the graph with an edge to node. Each
+// predecessor is checked against its own edges, since a concurrent AddEdge and
+// RemoveNeighbor may leave an entry for an edge that no longer exists.
+func (g *IntGraph) predecessors(node Node) []Node {
+	preds := []Node{}
+	if rn, ok := node.(ReverseNode); ok {
+		for p := range rn.Predecessors() {
+			if g.HasNode(p) && p.HasNeighbor(node) {
+				preds = append(preds, p)
+			}
+		}
+		return preds
+	}
+	for _, n := range g.nodeList() {
+		if n.HasNeighbor(node) {
+			preds = append(preds, n)
+		}
+	}
+	return preds
+}
+
+// Predecessors returns the Nodes in the graph with an edge to node
+func (g *IntGraph) Predecessors(node Node) ([]Node, error) {
 	if !g.HasNode(node) {
 		return nil, MissingNodeError{g, node}
 	}
-	visited := map[Node]struct{}{}
-	return g.dfs(node, sf, visited)
+	return g.predecessors(node), nil
 }
 
-func (g *IntGraph) dfs(node Node, sf SearchFunc, visited map[Node]struct{}) (interface{}, error) {
-	if value, done := sf(node); done {
-		return value, nil
+// InDegree returns the number of edges into node from Nodes in the graph
+func (g *IntGraph) InDegree(node Node) (int, error) {
+	preds, err := g.Predecessors(node)
+	return len(preds), err
+}
+
+// OutDegree returns the number of edges from node to Nodes in the graph
+func (g *IntGraph) OutDegree(node Node) (int, error) {
+	if !g.HasNode(node) {
+		return 0, MissingNodeError{g, node}
 	}
-	visited[node] = struct{}{}
+	degree := 0
 	for nbr := range node.Neighbors() {
-		if _, ok := visited[nbr]; !ok {
-			return g.dfs(nbr, sf, visited)
+		if g.HasNode(nbr) {
+			degree++
 		}
 	}
-	return nil, NotFoundError{"Search exhausted graph: objective not found"}
+	return degree, nil
+}
+
+// DFS executes a depth-first search, applying the SearchFunc to each IntNode
+// visited to yield values and determine whether or not to continue.
+func (g *IntGraph) DFS(node Node, sf SearchFunc) (interface{}, error) {
+	return g.DFSContext(context.Background(), node, sf)
 }
 
 // BFS executes a breadth-first search, applying the SearchFunc to each IntNode
 // visited to yield values and determine whether or not to continue.
 func (g *IntGraph) BFS(node Node, sf SearchFunc) (interface{}, error) {
-	if !g.HasNode(node) {
-		return nil, MissingNodeError{g, node}
-	}
-	visited := map[Node]struct{}{}
-	queue := []Node{node}
-	done := false
-	for len(queue) > 0 || done {
-		curr := queue[0]
-		if value, done := sf(curr); done {
-			return value, nil
-		}
-		visited[curr] = struct{}{}
-		queue = queue[1:]
-		for n := range curr.Neighbors() {
-			if _, ok := visited[n]; !ok {
-				queue = append(queue, n)
-			}
-		}
-	}
-	return nil, NotFoundError{"Search exhausted graph: objective not found"}
+	return g.BFSContext(context.Background(), node, sf)
 }
 
 // RouteExists (4.1) returns true if a route from start to finish exists
 func (g *IntGraph) RouteExists(start Node, finish Node) bool {
-	if !g.HasNode(start) || !g.HasNode(finish) {
-		return false
-	}
-	if _, err := g.BFS(start, func(node Node) (interface{}, bool) {
-		if node == finish {
-			return nil, true
-		}
-		return nil, false
-	}); err == nil {
-		return true
-	}
-	return false
+	exists, _ := g.RouteExistsContext(context.Background(), start, finish)
+	return exists
 }
 
 // NewIntBSTNode returns a new *IntBSTNode
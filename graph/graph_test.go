@@ -1,6 +1,9 @@
 package graph
 
-import "testing"
+import (
+	"sync"
+	"testing"
+)
 
 var nodeNeighborTests = []struct {
 	nodes  []int
@@ -401,3 +404,312 @@ func TestMinBST(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexedIntGraph(t *testing.T) {
+	g := NewIndexedIntGraph()
+	a := NewIntNode(1)
+	g.Insert(a)
+	g.Insert(NewIntNode(1))
+	if g.Size() != 1 {
+		t.Errorf("Insert: expected duplicate value to be ignored, actual size %v", g.Size())
+	}
+	if n, ok := g.Get(1); !ok || n != a {
+		t.Errorf("Get: expected %v, actual %v", a, n)
+	}
+	b := g.GetOrCreate(2)
+	if g.GetOrCreate(2) != b || g.Size() != 2 || !g.HasNode(b) {
+		t.Errorf("GetOrCreate: expected a single node with value 2")
+	}
+	if err := g.AddEdgeByID(1, 2, 3, nil); err != nil {
+		t.Error(err)
+	}
+	if e, ok := a.Edge(b); !ok || e.Weight != 3 {
+		t.Errorf("AddEdgeByID: expected edge 1->2 weighing 3, actual %v", e)
+	}
+	if err := g.AddNeighborByID(2, 3); err == nil {
+		t.Errorf("AddNeighborByID: expected MissingIDError, actual nil")
+	} else if _, ok := err.(MissingIDError); !ok {
+		t.Errorf("AddNeighborByID: expected MissingIDError, actual %v", err)
+	}
+	if err := g.RemoveNeighborByID(1, 2); err != nil || a.HasNeighbor(b) {
+		t.Errorf("RemoveNeighborByID: expected edge 1->2 to be removed (%v)", err)
+	}
+	g.Remove(a)
+	if _, ok := g.Get(1); ok {
+		t.Errorf("Remove: expected ID 1 to be unindexed")
+	}
+	c := NewIntNode(1)
+	g.Insert(c)
+	if n, _ := g.Get(1); n != c {
+		t.Errorf("Insert: expected ID 1 to be reusable after Remove")
+	}
+}
+
+func TestIndexedUndirectedIntGraph(t *testing.T) {
+	g := NewIndexedUndirectedIntGraph()
+	g.GetOrCreate(1)
+	g.GetOrCreate(2)
+	g.AddNeighborByID(1, 2)
+	a, _ := g.Get(1)
+	b, _ := g.Get(2)
+	if !a.HasNeighbor(b) || !b.HasNeighbor(a) {
+		t.Errorf("AddNeighborByID: expected symmetric edges")
+	}
+	// Get falls back to a scan in graphs that are not indexed
+	h := NewIntGraph()
+	h.Insert(NewIntNode(5))
+	if n, ok := h.Get(5); !ok || n.Value() != 5 {
+		t.Errorf("Get: expected node with value 5, actual %v", n)
+	}
+}
+
+var removeTests = []struct {
+	nodes  []int
+	edges  [][]int
+	remove int
+	in     []int
+	out    []int
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{
+			[]int{1, 2},
+			[]int{2},
+			[]int{0, 3},
+			[]int{2},
+		},
+		2,
+		[]int{0, 1, 0, 0},
+		[]int{1, 0, 0, 0},
+	},
+	{
+		[]int{0, 1, 2},
+		[][]int{
+			[]int{0, 1},
+			[]int{0},
+			[]int{},
+		},
+		0,
+		[]int{0, 0, 0},
+		[]int{0, 0, 0},
+	},
+}
+
+func TestRemove(t *testing.T) {
+	for _, tt := range removeTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		removed := nodes[tt.remove]
+		g.Remove(removed)
+		if g.HasNode(removed) || len(removed.Neighbors()) != 0 || len(removed.Predecessors()) != 0 {
+			t.Errorf("Remove: expected %v to be detached", removed)
+		}
+		if _, err := g.InDegree(removed); err == nil {
+			t.Errorf("InDegree: expected MissingNodeError, actual nil")
+		}
+		for i, n := range nodes {
+			if i == tt.remove {
+				continue
+			}
+			if n.HasNeighbor(removed) {
+				t.Errorf("Remove: expected no edge %v->%v", n, removed)
+			}
+			if in, _ := g.InDegree(n); in != tt.in[i] {
+				t.Errorf("InDegree(%v): expected %v, actual %v", i, tt.in[i], in)
+			}
+			if out, _ := g.OutDegree(n); out != tt.out[i] {
+				t.Errorf("OutDegree(%v): expected %v, actual %v", i, tt.out[i], out)
+			}
+		}
+	}
+}
+
+func TestPredecessors(t *testing.T) {
+	g, nodes := newIntGraph([]int{0, 1, 2}, [][]int{{2}, {2}, {}})
+	preds, err := g.Predecessors(nodes[2])
+	if err != nil || len(preds) != 2 {
+		t.Errorf("Predecessors: expected 0 and 1, actual %v (%v)", preds, err)
+	}
+	nodes[0].RemoveNeighbor(nodes[2])
+	if preds, _ := g.Predecessors(nodes[2]); len(preds) != 1 || preds[0] != nodes[1] {
+		t.Errorf("Predecessors: expected 1, actual %v", preds)
+	}
+}
+
+func TestPredecessorsConcurrent(t *testing.T) {
+	g := NewIndexedIntGraph()
+	target := g.GetOrCreate(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 200; i++ {
+			g.AddNeighbor(g.GetOrCreate(i), target)
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		g.InDegree(target)
+	}
+	<-done
+	if in, _ := g.InDegree(target); in != 200 {
+		t.Errorf("InDegree: expected 200, actual %v", in)
+	}
+}
+
+func TestPredecessorsRace(t *testing.T) {
+	g := NewIndexedIntGraph()
+	from, to := g.GetOrCreate(0), g.GetOrCreate(1)
+	// An entry left behind by a racing RemoveNeighbor is not counted
+	to.(*IntNode).addPredecessor(from)
+	if in, _ := g.InDegree(to); in != 0 {
+		t.Errorf("InDegree: expected 0 for a stale predecessor, actual %v", in)
+	}
+	for i := 0; i < 200; i++ {
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			from.AddNeighbor(to)
+		}()
+		go func() {
+			defer wg.Done()
+			from.RemoveNeighbor(to)
+		}()
+		wg.Wait()
+		exp := 0
+		if from.HasNeighbor(to) {
+			exp = 1
+		}
+		if in, _ := g.InDegree(to); in != exp {
+			t.Fatalf("InDegree: expected %v, actual %v", exp, in)
+		}
+	}
+}
+
+func TestAdjacent(t *testing.T) {
+	g, nodes := newIntGraph([]int{0, 1, 2}, [][]int{{2, 1}, {}, {}})
+	g.OrderByInsertion()
+	outside := NewIntNode(3)
+	nodes[0].AddNeighbor(outside)
+	if act, err := g.Adjacent(nodes[0]); err != nil || !equalInts(pathValues(act), []int{2, 1}) {
+		t.Errorf("Adjacent: expected [2 1], actual %v (%v)", pathValues(act), err)
+	}
+	if _, err := g.Adjacent(outside); err == nil {
+		t.Errorf("Adjacent: expected MissingNodeError, actual nil")
+	}
+	if act := pathValues(g.Nodes()); !equalInts(act, []int{0, 1, 2}) {
+		t.Errorf("Nodes: expected [0 1 2], actual %v", act)
+	}
+}
+
+var neighborOrderTests = []struct {
+	nodes []int
+	edges [][]int
+	less  func(a, b Node) bool
+	dfs   []int
+	bfs   []int
+}{
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{
+			[]int{3, 1, 2},
+			[]int{4},
+			[]int{4},
+			[]int{},
+			[]int{},
+		},
+		nil,
+		[]int{0, 3, 1, 4, 2},
+		[]int{0, 3, 1, 2, 4},
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{
+			[]int{3, 1, 2},
+			[]int{4},
+			[]int{4},
+			[]int{},
+			[]int{},
+		},
+		func(a, b Node) bool { return ValueLess(b, a) },
+		[]int{0, 3, 2, 4, 1},
+		[]int{0, 3, 2, 1, 4},
+	},
+}
+
+func TestNeighborOrder(t *testing.T) {
+	for _, tt := range neighborOrderTests {
+		// Map iteration order varies, so repeat to catch nondeterminism
+		for i := 0; i < 20; i++ {
+			g, nodes := newIntGraph(tt.nodes, tt.edges)
+			if tt.less == nil {
+				g.OrderByInsertion()
+			} else {
+				g.OrderBy(tt.less)
+			}
+			dfs, bfs := []int{}, []int{}
+			g.DFS(nodes[0], func(n Node) (interface{}, bool) {
+				dfs = append(dfs, n.Value().(int))
+				return nil, false
+			})
+			g.BFS(nodes[0], func(n Node) (interface{}, bool) {
+				bfs = append(bfs, n.Value().(int))
+				return nil, false
+			})
+			if !equalInts(dfs, tt.dfs) || !equalInts(bfs, tt.bfs) {
+				t.Errorf("Order: expected DFS %v and BFS %v, actual %v and %v", tt.dfs, tt.bfs, dfs, bfs)
+				break
+			}
+			// Both 0->1->4 and 0->2->4 cost 2; the first neighbor in order wins
+			path, _, _ := g.ShortestPath(nodes[0], nodes[4])
+			if act := pathValues(path); act[1] != tt.dfs[2] {
+				t.Errorf("ShortestPath: expected path via %v, actual %v", tt.dfs[2], act)
+				break
+			}
+		}
+	}
+}
+
+func TestRemoveNeighborOrder(t *testing.T) {
+	g := NewIndexedIntGraph()
+	hub := g.GetOrCreate(10)
+	for i := 0; i < 10; i++ {
+		g.AddNeighbor(hub, g.GetOrCreate(i))
+	}
+	snap := g.Snapshot()
+	for i := 0; i < 10; i += 2 {
+		g.RemoveNeighborByID(10, i)
+	}
+	g.AddNeighborByID(10, 0)
+	if act := pathValues(hub.(OrderedNode).OrderedNeighbors()); !equalInts(act, []int{1, 3, 5, 7, 9, 0}) {
+		t.Errorf("OrderedNeighbors: expected [1 3 5 7 9 0], actual %v", act)
+	}
+	s, _ := snap.Get(10)
+	if act := pathValues(s.(OrderedNode).OrderedNeighbors()); !equalInts(act, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Snapshot: expected [0 1 2 3 4 5 6 7 8 9], actual %v", act)
+	}
+}
+
+// BenchmarkRemove removes the Node that is last in each other Node's order
+func BenchmarkRemove(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		g := Complete(1000, false)
+		n, _ := g.Get(999)
+		b.StartTimer()
+		g.Remove(n)
+	}
+}
+
+func TestNodeListOrder(t *testing.T) {
+	g := NewIntGraph()
+	for _, v := range []int{5, 3, 9, 1} {
+		g.Insert(NewIntNode(v))
+	}
+	g.OrderByInsertion()
+	if act := pathValues(g.nodeList()); !equalInts(act, []int{5, 3, 9, 1}) {
+		t.Errorf("OrderByInsertion: expected [5 3 9 1], actual %v", act)
+	}
+	g.OrderBy(ValueLess)
+	if act := pathValues(g.nodeList()); !equalInts(act, []int{1, 3, 5, 9}) {
+		t.Errorf("OrderBy: expected [1 3 5 9], actual %v", act)
+	}
+}
@@ -0,0 +1,353 @@
+package graph
+
+import (
+	"fmt"
+	"iter"
+	"sort"
+)
+
+// TraversalOption configures the order in which a traversal visits Nodes, or
+// bounds how much of the graph it may visit.
+type TraversalOption func(*traversal)
+
+// traversal holds the configuration and budget of a single traversal. A
+// negative maxDepth or maxVisits means no limit. limited is set when the
+// traversal leaves Nodes unvisited because of those limits.
+type traversal struct {
+	graph     *IntGraph
+	less      func(a, b Node) bool
+	maxDepth  int
+	maxVisits int
+	visits    int
+	limited   bool
+}
+
+// SortedBy makes a traversal visit each Node's neighbors in the order given by
+// less, overriding the IntGraph's own order for that traversal.
+func SortedBy(less func(a, b Node) bool) TraversalOption {
+	return func(t *traversal) {
+		t.less = less
+	}
+}
+
+// MaxDepth stops a traversal from following paths of more than depth edges
+// from its start.
+func MaxDepth(depth int) TraversalOption {
+	return func(t *traversal) {
+		t.maxDepth = depth
+	}
+}
+
+// MaxVisits stops a traversal once it has visited the given number of Nodes
+func MaxVisits(visits int) TraversalOption {
+	return func(t *traversal) {
+		t.maxVisits = visits
+	}
+}
+
+// ValueLess orders Nodes by value. Int values are compared numerically; any
+// other values are compared by their string representations.
+func ValueLess(a, b Node) bool {
+	av, aok := a.Value().(int)
+	bv, bok := b.Value().(int)
+	if aok && bok {
+		return av < bv
+	}
+	return fmt.Sprint(a.Value()) < fmt.Sprint(b.Value())
+}
+
+func newTraversal(g *IntGraph, opts []TraversalOption) *traversal {
+	t := &traversal{graph: g, maxDepth: -1, maxVisits: -1}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// reset clears the traversal's budget, so that an iterator may be reused
+func (t *traversal) reset() {
+	t.visits = 0
+	t.limited = false
+}
+
+// neighbors returns node's neighbors in the traversal's order
+func (t *traversal) neighbors(node Node) []Node {
+	nbrs := t.graph.adjacent(node)
+	if t.less != nil {
+		sort.SliceStable(nbrs, func(i, j int) bool { return t.less(nbrs[i], nbrs[j]) })
+	}
+	return nbrs
+}
+
+// visit counts a visit, returning false if the visit budget is spent
+func (t *traversal) visit() bool {
+	if t.maxVisits >= 0 && t.visits >= t.maxVisits {
+		t.limited = true
+		return false
+	}
+	t.visits++
+	return true
+}
+
+// expand returns true if the neighbors of node, found at depth, may be
+// visited
+func (t *traversal) expand(node Node, depth int) bool {
+	return t.maxDepth < 0 || depth < t.maxDepth
+}
+
+// unreached returns true if any Node of cut, left unexpanded at the depth
+// limit, has a neighbor that the traversal did not reach. Only then did the
+// limit hide part of the graph.
+func (t *traversal) unreached(cut map[Node]struct{}, reached func(Node) bool) bool {
+	for node := range cut {
+		for _, nbr := range t.graph.adjacent(node) {
+			if !reached(nbr) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PreOrder returns an iterator over the Nodes reachable from start in
+// depth-first pre-order: each Node is yielded before any of its descendants.
+// If the graph does not contain start, the iterator yields nothing.
+func (g *IntGraph) PreOrder(start Node, opts ...TraversalOption) iter.Seq[Node] {
+	t := newTraversal(g, opts)
+	return func(yield func(Node) bool) {
+		for _, n := range g.preOrder(start, t) {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+func (g *IntGraph) preOrder(start Node, t *traversal) iter.Seq2[int, Node] {
+	type entry struct {
+		node  Node
+		depth int
+	}
+	return func(yield func(int, Node) bool) {
+		t.reset()
+		if !g.HasNode(start) {
+			return
+		}
+		// depth records the shallowest depth each Node was reached at. Under
+		// MaxDepth, a Node first reached at the limit is expanded again if a
+		// shorter path reaches it, without being yielded again; cut holds the
+		// Nodes left unexpanded at the limit.
+		depth := map[Node]int{}
+		cut := map[Node]struct{}{}
+		reached := func(n Node) bool {
+			_, ok := depth[n]
+			return ok
+		}
+		stack := []entry{{start, 0}}
+		for len(stack) > 0 {
+			curr := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			d, seen := depth[curr.node]
+			if seen && (t.maxDepth < 0 || d <= curr.depth) {
+				continue
+			}
+			depth[curr.node] = curr.depth
+			if !seen {
+				if !t.visit() {
+					return
+				}
+				if !yield(curr.depth, curr.node) {
+					return
+				}
+			}
+			if !t.expand(curr.node, curr.depth) {
+				cut[curr.node] = struct{}{}
+				continue
+			}
+			delete(cut, curr.node)
+			nbrs := t.neighbors(curr.node)
+			for i := len(nbrs) - 1; i >= 0; i-- {
+				if d, ok := depth[nbrs[i]]; !ok || t.maxDepth >= 0 && d > curr.depth+1 {
+					stack = append(stack, entry{nbrs[i], curr.depth + 1})
+				}
+			}
+		}
+		t.limited = t.unreached(cut, reached)
+	}
+}
+
+// PostOrder returns an iterator over the Nodes reachable from start in
+// depth-first post-order: each Node is yielded after all of its descendants.
+// If the graph does not contain start, the iterator yields nothing.
+func (g *IntGraph) PostOrder(start Node, opts ...TraversalOption) iter.Seq[Node] {
+	t := newTraversal(g, opts)
+	// A frame is revisited when a Node already yielded or on the stack is
+	// reached by a shorter path under MaxDepth, and is expanded again without
+	// being yielded again.
+	type frame struct {
+		node    Node
+		nbrs    []Node
+		revisit bool
+	}
+	return func(yield func(Node) bool) {
+		t.reset()
+		if !g.HasNode(start) || !t.visit() {
+			return
+		}
+		// depth and cut play the same parts as in preOrder
+		depth := map[Node]int{start: 0}
+		cut := map[Node]struct{}{}
+		reached := func(n Node) bool {
+			_, ok := depth[n]
+			return ok
+		}
+		push := func(stack []frame, node Node, revisit bool) []frame {
+			f := frame{node, nil, revisit}
+			if t.expand(node, len(stack)) {
+				f.nbrs = t.neighbors(node)
+				delete(cut, node)
+			} else {
+				cut[node] = struct{}{}
+			}
+			return append(stack, f)
+		}
+		stack := push(nil, start, false)
+		refused := false
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if len(top.nbrs) == 0 {
+				stack = stack[:len(stack)-1]
+				if !top.revisit && !yield(top.node) {
+					return
+				}
+				continue
+			}
+			next := top.nbrs[0]
+			top.nbrs = top.nbrs[1:]
+			d, seen := depth[next]
+			if seen && (t.maxDepth < 0 || d <= len(stack)) {
+				continue
+			}
+			if !seen && !t.visit() {
+				// Unwind, yielding the Nodes already on the stack
+				refused = true
+				for i := range stack {
+					stack[i].nbrs = nil
+				}
+				continue
+			}
+			depth[next] = len(stack)
+			stack = push(stack, next, seen)
+		}
+		t.limited = refused || t.unreached(cut, reached)
+	}
+}
+
+// BreadthFirst returns an iterator over the Nodes reachable from start in
+// breadth-first order, paired with their depth, i.e. their distance in edges
+// from start. If the graph does not contain start, the iterator yields
+// nothing.
+func (g *IntGraph) BreadthFirst(start Node, opts ...TraversalOption) iter.Seq2[int, Node] {
+	return g.breadthFirst(start, newTraversal(g, opts))
+}
+
+func (g *IntGraph) breadthFirst(start Node, t *traversal) iter.Seq2[int, Node] {
+	return func(yield func(int, Node) bool) {
+		t.reset()
+		if !g.HasNode(start) {
+			return
+		}
+		visited := map[Node]struct{}{start: {}}
+		cut := map[Node]struct{}{}
+		level := []Node{start}
+		for depth := 0; len(level) > 0; depth++ {
+			next := []Node{}
+			for _, curr := range level {
+				if !t.visit() || !yield(depth, curr) {
+					return
+				}
+				if !t.expand(curr, depth) {
+					cut[curr] = struct{}{}
+					continue
+				}
+				for _, nbr := range t.neighbors(curr) {
+					if _, ok := visited[nbr]; !ok {
+						visited[nbr] = struct{}{}
+						next = append(next, nbr)
+					}
+				}
+			}
+			level = next
+		}
+		t.limited = t.unreached(cut, func(n Node) bool {
+			_, ok := visited[n]
+			return ok
+		})
+	}
+}
+
+// IterativeDeepening returns an iterator over the Nodes reachable from start,
+// paired with their depth, found by depth-limited searches of increasing
+// depth. Nodes are yielded in order of depth, as in BreadthFirst. Unlike
+// BreadthFirst, it never holds a whole level of Nodes in a queue, but it
+// still records the depth of each Node reached, taking O(V) memory. A negative
+// maxDepth means no limit. If the graph does not contain start, the iterator
+// yields nothing.
+func (g *IntGraph) IterativeDeepening(start Node, maxDepth int, opts ...TraversalOption) iter.Seq2[int, Node] {
+	t := newTraversal(g, opts)
+	if maxDepth >= 0 && (t.maxDepth < 0 || maxDepth < t.maxDepth) {
+		t.maxDepth = maxDepth
+	}
+	return func(yield func(int, Node) bool) {
+		t.reset()
+		if !g.HasNode(start) {
+			return
+		}
+		yielded := map[Node]struct{}{}
+		for limit := 0; t.maxDepth < 0 || limit <= t.maxDepth; limit++ {
+			// depth records the shallowest depth at which each Node has been
+			// reached during this search, so that no subtree is searched
+			// twice; cut holds the Nodes left at the limit
+			depth := map[Node]int{}
+			cut := map[Node]struct{}{}
+			var search func(Node, int) bool
+			search = func(n Node, d int) bool {
+				if old, ok := depth[n]; ok && old <= d {
+					return true
+				}
+				depth[n] = d
+				if d == limit {
+					if _, ok := yielded[n]; !ok {
+						if !t.visit() {
+							return false
+						}
+						yielded[n] = struct{}{}
+						if !yield(d, n) {
+							return false
+						}
+					}
+					cut[n] = struct{}{}
+					return true
+				}
+				delete(cut, n)
+				for _, nbr := range t.neighbors(n) {
+					if !search(nbr, d+1) {
+						return false
+					}
+				}
+				return true
+			}
+			if !search(start, 0) {
+				return
+			}
+			// Deepen only while some Node at the limit leads somewhere new
+			if !t.unreached(cut, func(n Node) bool {
+				_, ok := depth[n]
+				return ok
+			}) {
+				return
+			}
+		}
+		t.limited = true
+	}
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"iter"
+	"testing"
+)
+
+var traversalTests = []struct {
+	nodes     []int
+	edges     [][]int
+	preOrder  []int
+	postOrder []int
+	bfs       []int
+	depths    []int
+}{
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]int{
+			[]int{2, 1},
+			[]int{3, 4},
+			[]int{4},
+			[]int{0},
+			[]int{5},
+			[]int{},
+		},
+		[]int{0, 1, 3, 4, 5, 2},
+		[]int{3, 5, 4, 1, 2, 0},
+		[]int{0, 1, 2, 3, 4, 5},
+		[]int{0, 1, 1, 2, 2, 3},
+	},
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{
+			[]int{1},
+			[]int{0},
+			[]int{3},
+			[]int{},
+		},
+		[]int{0, 1},
+		[]int{1, 0},
+		[]int{0, 1},
+		[]int{0, 1},
+	},
+}
+
+func TestTraversals(t *testing.T) {
+	for _, tt := range traversalTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		start := nodes[0]
+		order := SortedBy(ValueLess)
+		pre := []Node{}
+		for n := range g.PreOrder(start, order) {
+			pre = append(pre, n)
+		}
+		if act := pathValues(pre); !equalInts(act, tt.preOrder) {
+			t.Errorf("PreOrder: expected %v, actual %v", tt.preOrder, act)
+		}
+		post := []Node{}
+		for n := range g.PostOrder(start, order) {
+			post = append(post, n)
+		}
+		if act := pathValues(post); !equalInts(act, tt.postOrder) {
+			t.Errorf("PostOrder: expected %v, actual %v", tt.postOrder, act)
+		}
+		for name, seq := range map[string]iter.Seq2[int, Node]{
+			"BreadthFirst":       g.BreadthFirst(start, order),
+			"IterativeDeepening": g.IterativeDeepening(start, -1, order),
+		} {
+			visited, depths := []Node{}, []int{}
+			for d, n := range seq {
+				visited = append(visited, n)
+				depths = append(depths, d)
+			}
+			if act := pathValues(visited); !equalInts(act, tt.bfs) || !equalInts(depths, tt.depths) {
+				t.Errorf("%s: expected %v at depths %v, actual %v at depths %v", name, tt.bfs, tt.depths, act, depths)
+			}
+		}
+	}
+}
+
+func TestIterativeDeepeningLimit(t *testing.T) {
+	g, nodes := newIntGraph(traversalTests[0].nodes, traversalTests[0].edges)
+	visited := []Node{}
+	for _, n := range g.IterativeDeepening(nodes[0], 1, SortedBy(ValueLess)) {
+		visited = append(visited, n)
+	}
+	if act := pathValues(visited); !equalInts(act, []int{0, 1, 2}) {
+		t.Errorf("IterativeDeepening: expected [0 1 2], actual %v", act)
+	}
+}
+
+func TestTraversalStop(t *testing.T) {
+	g, nodes := newIntGraph(traversalTests[0].nodes, traversalTests[0].edges)
+	count := 0
+	for range g.PreOrder(nodes[0]) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("PreOrder: expected to stop after 2 nodes, actual %v", count)
+	}
+	for range g.PostOrder(NewIntNode(9)) {
+		t.Errorf("PostOrder: expected no nodes for a missing start")
+	}
+}
+
+func TestPreOrderExpandsOnce(t *testing.T) {
+	// Each expansion sorts the Node's neighbors, so counting comparisons
+	// counts expansions: without MaxDepth, DFS expands each Node once, as
+	// BreadthFirst does
+	g := Complete(50, false)
+	g.OrderByInsertion()
+	start, _ := g.Get(0)
+	bfs, dfs := 0, 0
+	for range g.BreadthFirst(start, SortedBy(func(a, b Node) bool { bfs++; return ValueLess(a, b) })) {
+	}
+	for range g.PreOrder(start, SortedBy(func(a, b Node) bool { dfs++; return ValueLess(a, b) })) {
+	}
+	if dfs > bfs {
+		t.Errorf("PreOrder: expected at most %v comparisons, actual %v", bfs, dfs)
+	}
+}
+
+func BenchmarkDFSComplete(b *testing.B) {
+	g := Complete(200, false)
+	start, _ := g.Get(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.DFS(start, func(Node) (interface{}, bool) { return nil, false })
+	}
+}
@@ -0,0 +1,161 @@
+package graph
+
+// symmetricAdjacency returns the neighbors of each of nodes with every edge
+// treated as undirected, ignoring edges that leave nodes.
+func (g *IntGraph) symmetricAdjacency(nodes []Node) map[Node][]Node {
+	adj := make(map[Node][]Node, len(nodes))
+	seen := make(map[Node]map[Node]struct{}, len(nodes))
+	for _, n := range nodes {
+		adj[n] = []Node{}
+		seen[n] = map[Node]struct{}{}
+	}
+	link := func(a, b Node) {
+		if _, ok := seen[a][b]; !ok {
+			seen[a][b] = struct{}{}
+			adj[a] = append(adj[a], b)
+		}
+	}
+	for _, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if _, ok := adj[nbr]; ok {
+				link(n, nbr)
+				link(nbr, n)
+			}
+		}
+	}
+	return adj
+}
+
+// Bipartite returns a two-coloring of the IntGraph, mapping each Node to 0 or
+// 1 such that no edge joins two Nodes of the same color. Edges are treated as
+// undirected. If no such coloring exists, it returns an OddCycleError.
+func (g *IntGraph) Bipartite() (map[Node]int, error) {
+	nodes := g.nodeList()
+	return bipartite(nodes, g.symmetricAdjacency(nodes))
+}
+
+func bipartite(nodes []Node, adj map[Node][]Node) (map[Node]int, error) {
+	color := make(map[Node]int, len(nodes))
+	parent := map[Node]Node{}
+	for _, root := range nodes {
+		if _, ok := color[root]; ok {
+			continue
+		}
+		color[root] = 0
+		queue := []Node{root}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adj[u] {
+				c, ok := color[v]
+				if !ok {
+					color[v] = 1 - color[u]
+					parent[v] = u
+					queue = append(queue, v)
+				} else if c == color[u] {
+					return nil, OddCycleError{oddCycle(parent, u, v)}
+				}
+			}
+		}
+	}
+	return color, nil
+}
+
+// oddCycle returns the cycle closed by the edge u-v in a BFS tree, where u and
+// v are at the same depth or adjacent depths.
+func oddCycle(parent map[Node]Node, u, v Node) []Node {
+	ancestors := map[Node]struct{}{u: {}}
+	for a, ok := parent[u]; ok; a, ok = parent[a] {
+		ancestors[a] = struct{}{}
+	}
+	down := []Node{v}
+	lca := v
+	for {
+		if _, ok := ancestors[lca]; ok {
+			break
+		}
+		lca = parent[lca]
+		down = append(down, lca)
+	}
+	cycle := []Node{u}
+	for a := u; a != lca; {
+		a = parent[a]
+		cycle = append(cycle, a)
+	}
+	for i := len(down) - 2; i >= 0; i-- {
+		cycle = append(cycle, down[i])
+	}
+	return append(cycle, u)
+}
+
+// MaximumMatching returns a maximum matching of a bipartite IntGraph, a largest
+// set of edges no two of which share a Node, using the Hopcroft-Karp
+// algorithm. Edges are treated as undirected. The matching maps each matched
+// Node to its partner, in both directions. If the IntGraph is not bipartite, it
+// returns an OddCycleError.
+func (g *IntGraph) MaximumMatching() (map[Node]Node, error) {
+	color, err := g.Bipartite()
+	if err != nil {
+		return nil, err
+	}
+	nodes := g.nodeList()
+	adj := g.symmetricAdjacency(nodes)
+	left := []Node{}
+	for _, n := range nodes {
+		if color[n] == 0 {
+			left = append(left, n)
+		}
+	}
+	const inf = int(^uint(0) >> 1)
+	match := map[Node]Node{}
+	dist := map[Node]int{}
+	// bfs layers free left Nodes and alternating paths from them, returning
+	// true if some augmenting path exists
+	bfs := func() bool {
+		queue := []Node{}
+		for _, u := range left {
+			if _, ok := match[u]; !ok {
+				dist[u] = 0
+				queue = append(queue, u)
+			} else {
+				dist[u] = inf
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range adj[u] {
+				w, ok := match[v]
+				if !ok {
+					found = true
+				} else if dist[w] == inf {
+					dist[w] = dist[u] + 1
+					queue = append(queue, w)
+				}
+			}
+		}
+		return found
+	}
+	var dfs func(Node) bool
+	dfs = func(u Node) bool {
+		for _, v := range adj[u] {
+			w, ok := match[v]
+			if !ok || (dist[w] == dist[u]+1 && dfs(w)) {
+				match[u] = v
+				match[v] = u
+				return true
+			}
+		}
+		dist[u] = inf
+		return false
+	}
+	for bfs() {
+		for _, u := range left {
+			if _, ok := match[u]; !ok {
+				dfs(u)
+			}
+		}
+	}
+	return match, nil
+}
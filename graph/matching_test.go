@@ -0,0 +1,155 @@
+package graph
+
+import "testing"
+
+var bipartiteTests = []struct {
+	nodes []int
+	edges [][]int
+	odd   int
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{
+			[]int{1},
+			[]int{2},
+			[]int{3},
+			[]int{0},
+		},
+		0,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{
+			[]int{1},
+			[]int{2},
+			[]int{3},
+			[]int{4},
+			[]int{0},
+		},
+		5,
+	},
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]int{
+			[]int{1, 3},
+			[]int{},
+			[]int{1},
+			[]int{4},
+			[]int{5},
+			[]int{3},
+		},
+		3,
+	},
+	{
+		[]int{0, 1},
+		[][]int{
+			[]int{},
+			[]int{},
+		},
+		0,
+	},
+}
+
+func TestBipartite(t *testing.T) {
+	for _, tt := range bipartiteTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		color, err := g.Bipartite()
+		if tt.odd > 0 {
+			oce, ok := err.(OddCycleError)
+			if !ok {
+				t.Errorf("Bipartite: expected OddCycleError, actual %v", err)
+				continue
+			}
+			// The cycle must be closed, of the expected length, and made of edges
+			c := oce.Cycle
+			if len(c) != tt.odd+1 || c[0] != c[len(c)-1] {
+				t.Errorf("Bipartite: expected odd cycle of length %v, actual %v", tt.odd, c)
+			}
+			for i := 1; i < len(c); i++ {
+				if !c[i-1].HasNeighbor(c[i]) && !c[i].HasNeighbor(c[i-1]) {
+					t.Errorf("Bipartite: odd cycle %v uses missing edge %v-%v", c, c[i-1], c[i])
+				}
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		for i, es := range tt.edges {
+			for _, e := range es {
+				if color[nodes[i]] == color[nodes[e]] {
+					t.Errorf("Bipartite: %v and %v share color %v", i, e, color[nodes[i]])
+				}
+			}
+		}
+	}
+}
+
+var matchingTests = []struct {
+	nodes []int
+	edges [][]int
+	size  int
+}{
+	// Workers 0-3 and shifts 4-7
+	{
+		[]int{0, 1, 2, 3, 4, 5, 6, 7},
+		[][]int{
+			[]int{4, 5},
+			[]int{4},
+			[]int{5, 6, 7},
+			[]int{6},
+			[]int{},
+			[]int{},
+			[]int{},
+			[]int{},
+		},
+		4,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{
+			[]int{3},
+			[]int{3},
+			[]int{3, 4},
+			[]int{},
+			[]int{},
+		},
+		2,
+	},
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]int{
+			[]int{1},
+			[]int{2},
+			[]int{3},
+			[]int{4},
+			[]int{5},
+			[]int{},
+		},
+		3,
+	},
+}
+
+func TestMaximumMatching(t *testing.T) {
+	for _, tt := range matchingTests {
+		g, _ := newIntGraph(tt.nodes, tt.edges)
+		match, err := g.MaximumMatching()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(match) != 2*tt.size {
+			t.Errorf("MaximumMatching: expected %v pairs, actual %v", tt.size, len(match)/2)
+		}
+		for a, b := range match {
+			if match[b] != a || (!a.HasNeighbor(b) && !b.HasNeighbor(a)) {
+				t.Errorf("MaximumMatching: invalid pair %v-%v", a, b)
+			}
+		}
+	}
+	g, _ := newIntGraph(bipartiteTests[1].nodes, bipartiteTests[1].edges)
+	if _, err := g.MaximumMatching(); err == nil {
+		t.Errorf("MaximumMatching: expected OddCycleError, actual nil")
+	}
+}
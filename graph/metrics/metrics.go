@@ -0,0 +1,328 @@
+// Package metrics computes structural measures of a graph.IntGraph: degree
+// distributions, distance-based measures such as diameter and radius, and the
+// betweenness, closeness and PageRank centrality of its Nodes.
+//
+// Distances count edges, ignoring edge weights. Each measure works on a
+// snapshot of the graph taken when it is called, so that concurrent changes do
+// not tear its view, and reports results keyed by the graph's own Nodes.
+package metrics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nikovacevic/ctci/graph"
+)
+
+// DisconnectedError describes the case when a distance-based measure is
+// undefined because some Node cannot reach another.
+type DisconnectedError struct {
+	From graph.Node
+	To   graph.Node
+}
+
+func (err DisconnectedError) Error() string {
+	return fmt.Sprintf("Graph is not connected: no route from %v to %v", err.From, err.To)
+}
+
+// indexed is a snapshot of an IntGraph, with its Nodes numbered in the
+// IntGraph's order and its edges stored as adjacency lists of those numbers.
+type indexed struct {
+	nodes      []graph.Node
+	adj        [][]int
+	undirected bool
+}
+
+// index numbers the Nodes of a snapshot of g, then swaps each back for the
+// Node of g it stands for, unless g is itself a snapshot.
+func index(g *graph.IntGraph) indexed {
+	snap := g.Snapshot()
+	nodes := snap.Nodes()
+	ids := make(map[graph.Node]int, len(nodes))
+	for i, n := range nodes {
+		ids[n] = i
+	}
+	adj := make([][]int, len(nodes))
+	for i, n := range nodes {
+		nbrs, _ := snap.Adjacent(n)
+		for _, nbr := range nbrs {
+			if j, ok := ids[nbr]; ok {
+				adj[i] = append(adj[i], j)
+			}
+		}
+	}
+	if !g.IsSnapshot() {
+		for i, n := range nodes {
+			nodes[i] = n.(graph.SnapshotNode).Source()
+		}
+	}
+	return indexed{nodes, adj, snap.Undirected()}
+}
+
+// distances returns the number of edges on a shortest route from src to each
+// Node, or -1 if there is none.
+func (ix indexed) distances(src int) []int {
+	dist := make([]int, len(ix.nodes))
+	for i := range dist {
+		dist[i] = -1
+	}
+	dist[src] = 0
+	queue := []int{src}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range ix.adj[u] {
+			if dist[v] < 0 {
+				dist[v] = dist[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	return dist
+}
+
+// DegreeHistogram maps each out-degree to the number of Nodes with that
+// out-degree. In an undirected graph, this is the degree of each Node.
+func DegreeHistogram(g *graph.IntGraph) map[int]int {
+	ix := index(g)
+	hist := map[int]int{}
+	for _, nbrs := range ix.adj {
+		hist[len(nbrs)]++
+	}
+	return hist
+}
+
+// InDegreeHistogram maps each in-degree to the number of Nodes with that
+// in-degree.
+func InDegreeHistogram(g *graph.IntGraph) map[int]int {
+	ix := index(g)
+	in := make([]int, len(ix.nodes))
+	for _, nbrs := range ix.adj {
+		for _, v := range nbrs {
+			in[v]++
+		}
+	}
+	hist := map[int]int{}
+	for _, d := range in {
+		hist[d]++
+	}
+	return hist
+}
+
+// Eccentricity returns, for each Node, the greatest distance from it to any
+// other Node. It returns a DisconnectedError unless every Node can reach every
+// other, i.e. the graph is (strongly) connected.
+func Eccentricity(g *graph.IntGraph) (map[graph.Node]int, error) {
+	ix := index(g)
+	ecc := make(map[graph.Node]int, len(ix.nodes))
+	for u, node := range ix.nodes {
+		for v, d := range ix.distances(u) {
+			if d < 0 {
+				return nil, DisconnectedError{node, ix.nodes[v]}
+			}
+			ecc[node] = max(ecc[node], d)
+		}
+	}
+	return ecc, nil
+}
+
+// Diameter returns the greatest eccentricity of any Node in a connected graph
+func Diameter(g *graph.IntGraph) (int, error) {
+	ecc, err := Eccentricity(g)
+	if err != nil {
+		return 0, err
+	}
+	diameter := 0
+	for _, e := range ecc {
+		diameter = max(diameter, e)
+	}
+	return diameter, nil
+}
+
+// Radius returns the least eccentricity of any Node in a connected graph. The
+// radius of an empty graph is 0.
+func Radius(g *graph.IntGraph) (int, error) {
+	ecc, err := Eccentricity(g)
+	if err != nil || len(ecc) == 0 {
+		return 0, err
+	}
+	radius := math.MaxInt
+	for _, e := range ecc {
+		radius = min(radius, e)
+	}
+	return radius, nil
+}
+
+// Betweenness returns the betweenness centrality of each Node: the sum, over
+// every pair of other Nodes s and t, of the fraction of shortest routes from s
+// to t that pass through it. It uses Brandes' algorithm, in O(V*E) time. In an
+// undirected graph, each unordered pair is counted once.
+func Betweenness(g *graph.IntGraph) map[graph.Node]float64 {
+	ix := index(g)
+	n := len(ix.nodes)
+	score := make([]float64, n)
+	for s := 0; s < n; s++ {
+		// sigma counts shortest routes from s; stack holds Nodes in order of
+		// non-decreasing distance, so that dependencies accumulate backwards.
+		sigma := make([]float64, n)
+		dist := make([]int, n)
+		preds := make([][]int, n)
+		for i := range dist {
+			dist[i] = -1
+		}
+		sigma[s], dist[s] = 1, 0
+		stack := []int{}
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
+			stack = append(stack, u)
+			for _, v := range ix.adj[u] {
+				if dist[v] < 0 {
+					dist[v] = dist[u] + 1
+					queue = append(queue, v)
+				}
+				if dist[v] == dist[u]+1 {
+					sigma[v] += sigma[u]
+					preds[v] = append(preds[v], u)
+				}
+			}
+		}
+		delta := make([]float64, n)
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, u := range preds[w] {
+				delta[u] += sigma[u] / sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				score[w] += delta[w]
+			}
+		}
+	}
+	centrality := make(map[graph.Node]float64, n)
+	for i, node := range ix.nodes {
+		if ix.undirected {
+			score[i] /= 2
+		}
+		centrality[node] = score[i]
+	}
+	return centrality
+}
+
+// Closeness returns the closeness centrality of each Node: the inverse of its
+// mean distance to the Nodes it can reach. So that it stays comparable in a
+// disconnected graph, it is scaled by the fraction of other Nodes reachable
+// (the Wasserman-Faust formula). A Node that reaches no others scores 0.
+func Closeness(g *graph.IntGraph) map[graph.Node]float64 {
+	ix := index(g)
+	n := len(ix.nodes)
+	centrality := make(map[graph.Node]float64, n)
+	for u, node := range ix.nodes {
+		reached, total := 0, 0
+		for _, d := range ix.distances(u) {
+			if d > 0 {
+				reached++
+				total += d
+			}
+		}
+		if total == 0 {
+			centrality[node] = 0
+			continue
+		}
+		r := float64(reached)
+		centrality[node] = r / float64(total) * r / float64(n-1)
+	}
+	return centrality
+}
+
+// PageRankOption configures PageRank
+type PageRankOption func(*pageRank)
+
+type pageRank struct {
+	damping       float64
+	tolerance     float64
+	maxIterations int
+}
+
+// Damping sets the probability that the random surfer follows an edge rather
+// than jumping to a random Node. It must be in [0, 1); the default is 0.85.
+func Damping(d float64) PageRankOption {
+	return func(pr *pageRank) {
+		pr.damping = d
+	}
+}
+
+// Tolerance sets the total change in rank, summed over all Nodes, below which
+// PageRank has converged. The default is 1e-6.
+func Tolerance(tol float64) PageRankOption {
+	return func(pr *pageRank) {
+		pr.tolerance = tol
+	}
+}
+
+// MaxIterations bounds the number of power iterations PageRank runs before
+// giving up on convergence. The default is 100.
+func MaxIterations(n int) PageRankOption {
+	return func(pr *pageRank) {
+		pr.maxIterations = n
+	}
+}
+
+// PageRank returns the PageRank of each Node, computed by power iteration.
+// Ranks sum to 1. The rank of a Node with no out-edges is spread evenly over
+// every Node. If the ranks have not converged within the iteration bound,
+// PageRank returns the last ranks along with an error.
+func PageRank(g *graph.IntGraph, opts ...PageRankOption) (map[graph.Node]float64, error) {
+	pr := &pageRank{damping: 0.85, tolerance: 1e-6, maxIterations: 100}
+	for _, opt := range opts {
+		opt(pr)
+	}
+	if pr.damping < 0 || pr.damping >= 1 {
+		return nil, fmt.Errorf("PageRank damping must be in [0, 1), actual %v", pr.damping)
+	}
+	ix := index(g)
+	n := len(ix.nodes)
+	ranks := make(map[graph.Node]float64, n)
+	if n == 0 {
+		return ranks, nil
+	}
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	converged := false
+	for iter := 0; iter < pr.maxIterations && !converged; iter++ {
+		dangling := 0.0
+		for u, nbrs := range ix.adj {
+			if len(nbrs) == 0 {
+				dangling += rank[u]
+			}
+		}
+		base := (1-pr.damping)/float64(n) + pr.damping*dangling/float64(n)
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = base
+		}
+		for u, nbrs := range ix.adj {
+			if len(nbrs) == 0 {
+				continue
+			}
+			share := pr.damping * rank[u] / float64(len(nbrs))
+			for _, v := range nbrs {
+				next[v] += share
+			}
+		}
+		change := 0.0
+		for i := range rank {
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, converged = next, change < pr.tolerance
+	}
+	for i, node := range ix.nodes {
+		ranks[node] = rank[i]
+	}
+	if !converged {
+		return ranks, fmt.Errorf("PageRank did not converge within %d iterations", pr.maxIterations)
+	}
+	return ranks, nil
+}
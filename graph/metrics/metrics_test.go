@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nikovacevic/ctci/graph"
+)
+
+// newIntGraph builds an IntGraph with one Node per value in nodes, and an edge
+// from nodes[i] to nodes[j] for each j in edges[i].
+func newIntGraph(undirected bool, nodes []int, edges [][]int) (*graph.IntGraph, []*graph.IntNode) {
+	g := graph.NewIntGraph()
+	if undirected {
+		g = graph.NewUndirectedIntGraph()
+	}
+	ns := make([]*graph.IntNode, len(nodes))
+	for i, v := range nodes {
+		ns[i] = graph.NewIntNode(v)
+		g.Insert(ns[i])
+	}
+	for i, es := range edges {
+		for _, e := range es {
+			g.AddNeighbor(ns[i], ns[e])
+		}
+	}
+	return g, ns
+}
+
+func equalHist(a, b map[int]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+var metricsTests = []struct {
+	undirected  bool
+	nodes       []int
+	edges       [][]int
+	degrees     map[int]int
+	eccentric   []int
+	diameter    int
+	radius      int
+	betweenness []float64
+	closeness   []float64
+}{
+	// A path 0-1-2-3
+	{
+		true,
+		[]int{0, 1, 2, 3},
+		[][]int{{1}, {2}, {3}, {}},
+		map[int]int{1: 2, 2: 2},
+		[]int{3, 2, 2, 3},
+		3,
+		2,
+		[]float64{0, 2, 2, 0},
+		[]float64{0.5, 0.75, 0.75, 0.5},
+	},
+	// A star with center 0
+	{
+		true,
+		[]int{0, 1, 2, 3},
+		[][]int{{1, 2, 3}, {}, {}, {}},
+		map[int]int{3: 1, 1: 3},
+		[]int{1, 2, 2, 2},
+		2,
+		1,
+		[]float64{3, 0, 0, 0},
+		[]float64{1, 0.6, 0.6, 0.6},
+	},
+	// A directed cycle 0->1->2->0
+	{
+		false,
+		[]int{0, 1, 2},
+		[][]int{{1}, {2}, {0}},
+		map[int]int{1: 3},
+		[]int{2, 2, 2},
+		2,
+		2,
+		[]float64{1, 1, 1},
+		[]float64{2.0 / 3, 2.0 / 3, 2.0 / 3},
+	},
+}
+
+func TestMetrics(t *testing.T) {
+	for _, tt := range metricsTests {
+		g, nodes := newIntGraph(tt.undirected, tt.nodes, tt.edges)
+		if act := DegreeHistogram(g); !equalHist(act, tt.degrees) {
+			t.Errorf("DegreeHistogram: expected %v, actual %v", tt.degrees, act)
+		}
+		ecc, err := Eccentricity(g)
+		if err != nil {
+			t.Errorf("Eccentricity: expected nil error, actual %v", err)
+		}
+		if d, _ := Diameter(g); d != tt.diameter {
+			t.Errorf("Diameter: expected %v, actual %v", tt.diameter, d)
+		}
+		if r, _ := Radius(g); r != tt.radius {
+			t.Errorf("Radius: expected %v, actual %v", tt.radius, r)
+		}
+		between, close := Betweenness(g), Closeness(g)
+		for i, n := range nodes {
+			if ecc[n] != tt.eccentric[i] {
+				t.Errorf("Eccentricity(%v): expected %v, actual %v", i, tt.eccentric[i], ecc[n])
+			}
+			if !near(between[n], tt.betweenness[i]) {
+				t.Errorf("Betweenness(%v): expected %v, actual %v", i, tt.betweenness[i], between[n])
+			}
+			if !near(close[n], tt.closeness[i]) {
+				t.Errorf("Closeness(%v): expected %v, actual %v", i, tt.closeness[i], close[n])
+			}
+		}
+	}
+}
+
+func TestInDegreeHistogram(t *testing.T) {
+	g, _ := newIntGraph(false, []int{0, 1, 2, 3}, [][]int{{3}, {3}, {3}, {}})
+	exp := map[int]int{0: 3, 3: 1}
+	if act := InDegreeHistogram(g); !equalHist(act, exp) {
+		t.Errorf("InDegreeHistogram: expected %v, actual %v", exp, act)
+	}
+}
+
+func TestDisconnected(t *testing.T) {
+	g, nodes := newIntGraph(false, []int{0, 1, 2}, [][]int{{1}, {2}, {}})
+	if _, err := Diameter(g); err == nil {
+		t.Errorf("Diameter: expected DisconnectedError, actual nil")
+	} else if _, ok := err.(DisconnectedError); !ok {
+		t.Errorf("Diameter: expected DisconnectedError, actual %v", err)
+	}
+	// 0 reaches 1 and 2 at distances 1 and 2; 2 reaches nothing
+	close := Closeness(g)
+	if !near(close[nodes[0]], 2.0/3) || close[nodes[2]] != 0 {
+		t.Errorf("Closeness: expected 2/3 and 0, actual %v and %v", close[nodes[0]], close[nodes[2]])
+	}
+}
+
+func TestSnapshotNodes(t *testing.T) {
+	g, nodes := newIntGraph(false, []int{0, 1, 2}, [][]int{{1}, {2}, {0}})
+	// Measures of a live graph are keyed by its own Nodes...
+	if _, ok := Closeness(g)[nodes[0]]; !ok {
+		t.Errorf("Closeness: expected a result for %v", nodes[0])
+	}
+	// ... and measures of a snapshot by the snapshot's
+	snap := g.Snapshot()
+	s0, _ := snap.InSnapshot(nodes[0])
+	ecc, err := Eccentricity(snap)
+	if err != nil || ecc[s0] != 2 {
+		t.Errorf("Eccentricity: expected 2 for %v, actual %v (%v)", s0, ecc[s0], err)
+	}
+}
+
+var pageRankTests = []struct {
+	nodes []int
+	edges [][]int
+	ranks []float64
+}{
+	// A cycle is symmetric
+	{
+		[]int{0, 1, 2},
+		[][]int{{1}, {2}, {0}},
+		[]float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+	},
+	// Leaves all link to 0, which links nowhere, so with d=0.85 each leaf
+	// holds s = 0.0375 + 0.85*r/4 and 0 holds r = s + 0.85*3s = 3.55s
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{}, {0}, {0}, {0}},
+		[]float64{3.55 / 6.55, 1 / 6.55, 1 / 6.55, 1 / 6.55},
+	},
+}
+
+func TestPageRank(t *testing.T) {
+	for _, tt := range pageRankTests {
+		g, nodes := newIntGraph(false, tt.nodes, tt.edges)
+		ranks, err := PageRank(g, Tolerance(1e-10))
+		if err != nil {
+			t.Errorf("PageRank: expected nil error, actual %v", err)
+		}
+		sum := 0.0
+		for i, n := range nodes {
+			sum += ranks[n]
+			if !near(ranks[n], tt.ranks[i]) {
+				t.Errorf("PageRank(%v): expected %v, actual %v", i, tt.ranks[i], ranks[n])
+			}
+		}
+		if !near(sum, 1) {
+			t.Errorf("PageRank: expected ranks to sum to 1, actual %v", sum)
+		}
+	}
+}
+
+func TestPageRankOptions(t *testing.T) {
+	g, _ := newIntGraph(false, []int{0, 1, 2}, [][]int{{1}, {2}, {}})
+	if _, err := PageRank(g, Damping(1)); err == nil {
+		t.Errorf("PageRank: expected error for damping 1, actual nil")
+	}
+	if _, err := PageRank(g, Tolerance(0), MaxIterations(3)); err == nil {
+		t.Errorf("PageRank: expected convergence error, actual nil")
+	}
+	// Without damping every Node is equally likely
+	ranks, err := PageRank(g, Damping(0))
+	for n, r := range ranks {
+		if !near(r, 1.0/3) {
+			t.Errorf("PageRank(%v): expected 1/3, actual %v (%v)", n, r, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nikovacevic/ctci/unionfind"
+)
+
+// SpanningTree is a minimum spanning tree of an undirected graph: the set of
+// Edges selected to connect its Nodes, and their total Weight. If the graph is
+// disconnected, it is a minimum spanning forest, with one tree per component.
+type SpanningTree struct {
+	Edges  []Edge
+	Weight float64
+}
+
+// undirectedEdges returns each edge among nodes once, skipping self-loops
+func (g *IntGraph) undirectedEdges(nodes []Node) []Edge {
+	index := make(map[Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+	edges := []Edge{}
+	for i, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if j, ok := index[nbr]; ok && i < j {
+				edges = append(edges, Edge{From: n, To: nbr, Weight: weight(n, nbr)})
+			}
+		}
+	}
+	return edges
+}
+
+// Kruskal returns a minimum spanning tree of an undirected IntGraph, built by
+// adding the lightest remaining edge that joins two separate trees.
+func (g *IntGraph) Kruskal() (*SpanningTree, error) {
+	if !g.undirected {
+		return nil, fmt.Errorf("Kruskal requires an undirected graph")
+	}
+	nodes := g.nodeList()
+	edges := g.undirectedEdges(nodes)
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].Weight < edges[j].Weight
+	})
+	ds := unionfind.New(nodes...)
+	mst := &SpanningTree{Edges: []Edge{}}
+	for _, e := range edges {
+		if ds.Union(e.From, e.To) {
+			mst.Edges = append(mst.Edges, e)
+			mst.Weight += e.Weight
+		}
+	}
+	return mst, nil
+}
+
+// Prim returns a minimum spanning tree of an undirected IntGraph, grown from
+// an arbitrary Node by repeatedly taking the lightest edge leaving the tree.
+func (g *IntGraph) Prim() (*SpanningTree, error) {
+	if !g.undirected {
+		return nil, fmt.Errorf("Prim requires an undirected graph")
+	}
+	nodes := g.nodeList()
+	member := make(map[Node]struct{}, len(nodes))
+	for _, n := range nodes {
+		member[n] = struct{}{}
+	}
+	inTree := map[Node]bool{}
+	parent := map[Node]Node{}
+	key := map[Node]float64{}
+	mst := &SpanningTree{Edges: []Edge{}}
+	for _, root := range nodes {
+		if inTree[root] {
+			continue
+		}
+		pq := newNodeHeap(root, 0)
+		for pq.Len() > 0 {
+			curr := pq.pop().node
+			if inTree[curr] {
+				continue
+			}
+			inTree[curr] = true
+			if p, ok := parent[curr]; ok {
+				mst.Edges = append(mst.Edges, Edge{From: p, To: curr, Weight: key[curr]})
+				mst.Weight += key[curr]
+			}
+			for _, nbr := range g.adjacent(curr) {
+				if _, ok := member[nbr]; !ok || inTree[nbr] {
+					continue
+				}
+				w := weight(curr, nbr)
+				if k, ok := key[nbr]; !ok || w < k {
+					key[nbr] = w
+					parent[nbr] = curr
+					pq.push(nbr, w)
+				}
+			}
+		}
+	}
+	return mst, nil
+}
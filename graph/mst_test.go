@@ -0,0 +1,100 @@
+package graph
+
+import "testing"
+
+type undirectedEdge struct {
+	a, b   int
+	weight float64
+}
+
+// newUndirectedIntGraph builds an undirected IntGraph with one IntNode per
+// value in nodes, joined by each of edges.
+func newUndirectedIntGraph(nodes []int, edges []undirectedEdge) (*IntGraph, []*IntNode) {
+	ns := make([]*IntNode, len(nodes))
+	g := NewUndirectedIntGraph()
+	for i, n := range nodes {
+		ns[i] = NewIntNode(n)
+		g.Insert(ns[i])
+	}
+	for _, e := range edges {
+		g.AddEdge(ns[e.a], ns[e.b], e.weight, nil)
+	}
+	return g, ns
+}
+
+func TestUndirectedAddNeighbor(t *testing.T) {
+	g := NewUndirectedIntGraph()
+	a, b := NewIntNode(0), NewIntNode(1)
+	g.Insert(a)
+	g.Insert(b)
+	if err := g.AddNeighbor(a, b); err != nil {
+		t.Error(err)
+	}
+	if !a.HasNeighbor(b) || !b.HasNeighbor(a) {
+		t.Errorf("AddNeighbor: expected symmetric edges")
+	}
+	if err := g.AddNeighbor(a, NewIntNode(2)); err == nil {
+		t.Errorf("AddNeighbor: expected MissingNodeError, actual nil")
+	}
+	d := NewIntGraph()
+	d.Insert(a)
+	d.Insert(b)
+	a.RemoveNeighbor(b)
+	b.RemoveNeighbor(a)
+	d.AddNeighbor(a, b)
+	if !a.HasNeighbor(b) || b.HasNeighbor(a) {
+		t.Errorf("AddNeighbor: expected a directed edge")
+	}
+}
+
+var mstTests = []struct {
+	nodes  []int
+	edges  []undirectedEdge
+	weight float64
+	size   int
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[]undirectedEdge{{0, 1, 10}, {0, 2, 6}, {0, 3, 5}, {1, 3, 15}, {2, 3, 4}},
+		19,
+		3,
+	},
+	{
+		[]int{0, 1, 2, 3, 4, 5, 6},
+		[]undirectedEdge{
+			{0, 1, 7}, {0, 3, 5}, {1, 2, 8}, {1, 3, 9}, {1, 4, 7}, {2, 4, 5},
+			{3, 4, 15}, {3, 5, 6}, {4, 5, 8}, {4, 6, 9}, {5, 6, 11},
+		},
+		39,
+		6,
+	},
+	// A disconnected graph yields a spanning forest
+	{
+		[]int{0, 1, 2, 3, 4},
+		[]undirectedEdge{{0, 1, 1}, {1, 2, 2}, {0, 2, 3}, {3, 4, 4}},
+		7,
+		3,
+	},
+}
+
+func TestMST(t *testing.T) {
+	for _, tt := range mstTests {
+		g, _ := newUndirectedIntGraph(tt.nodes, tt.edges)
+		for name, mst := range map[string]func() (*SpanningTree, error){
+			"Kruskal": g.Kruskal,
+			"Prim":    g.Prim,
+		} {
+			tree, err := mst()
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if tree.Weight != tt.weight || len(tree.Edges) != tt.size {
+				t.Errorf("%s: expected %v edges weighing %v, actual %v weighing %v", name, tt.size, tt.weight, len(tree.Edges), tree.Weight)
+			}
+		}
+	}
+	if _, err := NewIntGraph().Kruskal(); err == nil {
+		t.Errorf("Kruskal: expected error for directed graph, actual nil")
+	}
+}
@@ -0,0 +1,120 @@
+package graph
+
+// TopologicalSort returns the Nodes of a directed acyclic IntGraph in an order
+// such that every edge points from an earlier Node to a later one, using Kahn's
+// algorithm. If the IntGraph contains a cycle, it returns a CycleError.
+func (g *IntGraph) TopologicalSort() ([]Node, error) {
+	nodes := g.nodeList()
+	indegree := make(map[Node]int, len(nodes))
+	for _, n := range nodes {
+		indegree[n] = 0
+	}
+	for _, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if _, ok := indegree[nbr]; ok {
+				indegree[nbr]++
+			}
+		}
+	}
+	queue := []Node{}
+	for _, n := range nodes {
+		if indegree[n] == 0 {
+			queue = append(queue, n)
+		}
+	}
+	order := make([]Node, 0, len(nodes))
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+		order = append(order, curr)
+		for _, nbr := range g.adjacent(curr) {
+			if _, ok := indegree[nbr]; !ok {
+				continue
+			}
+			indegree[nbr]--
+			if indegree[nbr] == 0 {
+				queue = append(queue, nbr)
+			}
+		}
+	}
+	if len(order) == len(nodes) {
+		return order, nil
+	}
+	// Every Node left with a positive in-degree is on, or downstream of, a cycle
+	remaining := []Node{}
+	for _, n := range nodes {
+		if indegree[n] > 0 {
+			remaining = append(remaining, n)
+		}
+	}
+	_, err := g.topologicalSortDFS(remaining)
+	return nil, err
+}
+
+// TopologicalSortDFS returns the Nodes of a directed acyclic IntGraph in
+// topological order, using depth-first search. If the IntGraph contains a
+// cycle, it returns a CycleError.
+func (g *IntGraph) TopologicalSortDFS() ([]Node, error) {
+	return g.topologicalSortDFS(g.nodeList())
+}
+
+// topologicalSortDFS sorts the subgraph induced by nodes, reporting the first
+// cycle found as a CycleError.
+func (g *IntGraph) topologicalSortDFS(nodes []Node) ([]Node, error) {
+	const (
+		unvisited = iota
+		onStack
+		finished
+	)
+	state := make(map[Node]int, len(nodes))
+	for _, n := range nodes {
+		state[n] = unvisited
+	}
+	order := make([]Node, 0, len(nodes))
+	stack := []Node{}
+	var visit func(Node) error
+	visit = func(n Node) error {
+		state[n] = onStack
+		stack = append(stack, n)
+		for _, nbr := range g.adjacent(n) {
+			s, ok := state[nbr]
+			if !ok {
+				continue
+			}
+			switch s {
+			case onStack:
+				return CycleError{cycleFrom(stack, nbr)}
+			case unvisited:
+				if err := visit(nbr); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[n] = finished
+		order = append(order, n)
+		return nil
+	}
+	for _, n := range nodes {
+		if state[n] == unvisited {
+			if err := visit(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, j := 0, len(order)-1; i < j; i, j = i+1, j-1 {
+		order[i], order[j] = order[j], order[i]
+	}
+	return order, nil
+}
+
+// cycleFrom returns the closed walk from node to the top of the DFS stack and
+// back to node, where node is somewhere on the stack.
+func cycleFrom(stack []Node, node Node) []Node {
+	i := len(stack) - 1
+	for stack[i] != node {
+		i--
+	}
+	cycle := append([]Node{}, stack[i:]...)
+	return append(cycle, node)
+}
@@ -0,0 +1,107 @@
+package graph
+
+import "testing"
+
+var topologicalSortTests = []struct {
+	nodes []int
+	edges [][]int
+	cycle []int
+}{
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]int{
+			[]int{1, 2},
+			[]int{3},
+			[]int{3, 4},
+			[]int{5},
+			[]int{5},
+			[]int{},
+		},
+		nil,
+	},
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{
+			[]int{},
+			[]int{},
+			[]int{},
+			[]int{},
+		},
+		nil,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{
+			[]int{1},
+			[]int{2},
+			[]int{3, 4},
+			[]int{1},
+			[]int{},
+		},
+		[]int{1, 2, 3, 1},
+	},
+	{
+		[]int{0, 1},
+		[][]int{
+			[]int{0},
+			[]int{},
+		},
+		[]int{0, 0},
+	},
+}
+
+// newIntGraph builds an unweighted IntGraph with one IntNode per value in
+// nodes, connecting nodes[i] to each of edges[i].
+func newIntGraph(nodes []int, edges [][]int) (*IntGraph, []*IntNode) {
+	ns := make([]*IntNode, len(nodes))
+	g := NewIntGraph()
+	for i, n := range nodes {
+		ns[i] = NewIntNode(n)
+		g.Insert(ns[i])
+	}
+	for i, es := range edges {
+		for _, e := range es {
+			ns[i].AddNeighbor(ns[e])
+		}
+	}
+	return g, ns
+}
+
+func TestTopologicalSort(t *testing.T) {
+	for _, tt := range topologicalSortTests {
+		g, _ := newIntGraph(tt.nodes, tt.edges)
+		for name, sort := range map[string]func() ([]Node, error){
+			"TopologicalSort":    g.TopologicalSort,
+			"TopologicalSortDFS": g.TopologicalSortDFS,
+		} {
+			order, err := sort()
+			if tt.cycle != nil {
+				ce, ok := err.(CycleError)
+				if !ok {
+					t.Errorf("%s: expected CycleError, actual %v", name, err)
+				} else if act := pathValues(ce.Cycle); !sameCycle(act, tt.cycle) {
+					t.Errorf("%s: expected cycle %v, actual %v", name, tt.cycle, act)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			if len(order) != len(tt.nodes) {
+				t.Errorf("%s: expected %v nodes, actual %v", name, len(tt.nodes), len(order))
+			}
+			position := map[int]int{}
+			for i, n := range pathValues(order) {
+				position[n] = i
+			}
+			for from, tos := range tt.edges {
+				for _, to := range tos {
+					if position[tt.nodes[from]] >= position[tt.nodes[to]] {
+						t.Errorf("%s: expected %v before %v, actual %v", name, from, to, pathValues(order))
+					}
+				}
+			}
+		}
+	}
+}
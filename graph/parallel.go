@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"runtime"
+	"sync"
+)
+
+// lockedNeighbors returns node's neighbors, read under node's lock when node
+// is an OrderedNode, so that it is safe to call from several goroutines.
+func lockedNeighbors(node Node) []Node {
+	if on, ok := node.(OrderedNode); ok {
+		return on.OrderedNeighbors()
+	}
+	return neighborList(node)
+}
+
+// ParallelBFS executes a level-synchronous breadth-first search from start,
+// returning the distance in edges from start to every reachable Node. Each
+// level's frontier is split among a pool of workers goroutines, which expand
+// their share of it concurrently; a workers value below 1 uses GOMAXPROCS.
+func (g *IntGraph) ParallelBFS(start Node, workers int) (map[Node]int, error) {
+	if !g.HasNode(start) {
+		return nil, MissingNodeError{g, start}
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	// member is read-only once built, so workers may share it without locking
+	member := map[Node]struct{}{}
+	for _, n := range g.nodeList() {
+		member[n] = struct{}{}
+	}
+	dist := map[Node]int{start: 0}
+	frontier := []Node{start}
+	for depth := 1; len(frontier) > 0; depth++ {
+		chunk := (len(frontier) + workers - 1) / workers
+		found := make([][]Node, workers)
+		var wg sync.WaitGroup
+		for w := 0; w < workers; w++ {
+			lo, hi := w*chunk, min((w+1)*chunk, len(frontier))
+			if lo >= hi {
+				break
+			}
+			wg.Add(1)
+			go func(w int, nodes []Node) {
+				defer wg.Done()
+				for _, n := range nodes {
+					for _, nbr := range lockedNeighbors(n) {
+						// dist is only written between levels, so reading it
+						// here is safe and prunes most already-visited Nodes
+						if _, ok := dist[nbr]; ok {
+							continue
+						}
+						if _, ok := member[nbr]; ok {
+							found[w] = append(found[w], nbr)
+						}
+					}
+				}
+			}(w, frontier[lo:hi])
+		}
+		wg.Wait()
+		frontier = frontier[:0:0]
+		for _, nodes := range found {
+			for _, n := range nodes {
+				if _, ok := dist[n]; !ok {
+					dist[n] = depth
+					frontier = append(frontier, n)
+				}
+			}
+		}
+	}
+	return dist, nil
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParallelBFS(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 8} {
+		g := ErdosRenyi(2000, 3.0/2000, false, int64(workers))
+		start, _ := g.Get(0)
+		exp := map[Node]int{}
+		for d, n := range g.BreadthFirst(start) {
+			exp[n] = d
+		}
+		act, err := g.ParallelBFS(start, workers)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(act) != len(exp) {
+			t.Errorf("ParallelBFS(%v workers): expected %v nodes, actual %v", workers, len(exp), len(act))
+		}
+		for n, d := range exp {
+			if act[n] != d {
+				t.Errorf("ParallelBFS(%v workers): expected distance %v to %v, actual %v", workers, d, n, act[n])
+				break
+			}
+		}
+	}
+	g, _ := newChainIntGraph(3)
+	if _, err := g.ParallelBFS(NewIntNode(0), 2); err == nil {
+		t.Errorf("ParallelBFS: expected MissingNodeError, actual nil")
+	}
+}
+
+func BenchmarkBFS(b *testing.B) {
+	g := ErdosRenyi(50000, 8.0/50000, false, 1)
+	start, _ := g.Get(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.BFS(start, func(Node) (interface{}, bool) { return nil, false })
+	}
+}
+
+func BenchmarkParallelBFS(b *testing.B) {
+	g := ErdosRenyi(50000, 8.0/50000, false, 1)
+	start, _ := g.Get(0)
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.ParallelBFS(start, workers)
+			}
+		})
+	}
+}
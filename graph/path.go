@@ -0,0 +1,267 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// nodeItem is a Node queued with a priority, e.g. its tentative distance.
+// seq records the order in which items were pushed, so that ties are popped
+// first-in, first-out and results follow the IntGraph's neighbor order.
+type nodeItem struct {
+	node     Node
+	priority float64
+	seq      int
+}
+
+// nodeHeap implements heap.Interface as a min-heap of nodeItems
+type nodeHeap struct {
+	items  []nodeItem
+	pushed int
+}
+
+func newNodeHeap(node Node, priority float64) *nodeHeap {
+	h := &nodeHeap{}
+	h.push(node, priority)
+	return h
+}
+
+func (h *nodeHeap) push(node Node, priority float64) {
+	heap.Push(h, nodeItem{node, priority, h.pushed})
+	h.pushed++
+}
+
+func (h *nodeHeap) pop() nodeItem {
+	return heap.Pop(h).(nodeItem)
+}
+
+func (h *nodeHeap) Len() int { return len(h.items) }
+func (h *nodeHeap) Less(i, j int) bool {
+	if h.items[i].priority != h.items[j].priority {
+		return h.items[i].priority < h.items[j].priority
+	}
+	return h.items[i].seq < h.items[j].seq
+}
+func (h *nodeHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *nodeHeap) Push(x interface{}) { h.items = append(h.items, x.(nodeItem)) }
+func (h *nodeHeap) Pop() interface{} {
+	old := h.items
+	item := old[len(old)-1]
+	h.items = old[:len(old)-1]
+	return item
+}
+
+// weight returns the weight of the edge from one Node to another. Nodes that
+// do not implement WeightedNode have edges of weight 1.
+func weight(from, to Node) float64 {
+	if wn, ok := from.(WeightedNode); ok {
+		if e, ok := wn.Edge(to); ok {
+			return e.Weight
+		}
+	}
+	return 1
+}
+
+// tracePath follows prev links back from finish to start, returning the path
+// in order from start to finish.
+func tracePath(prev map[Node]Node, start, finish Node) []Node {
+	path := []Node{finish}
+	for curr := finish; curr != start; {
+		curr = prev[curr]
+		path = append(path, curr)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// ShortestPath returns the least-cost path from start to finish, along with
+// its total cost, using Dijkstra's algorithm. All edge weights along the way
+// must be non-negative.
+func (g *IntGraph) ShortestPath(start, finish Node) ([]Node, float64, error) {
+	if !g.HasNode(start) {
+		return nil, 0, MissingNodeError{g, start}
+	}
+	if !g.HasNode(finish) {
+		return nil, 0, MissingNodeError{g, finish}
+	}
+	dist, prev, err := g.dijkstra(start, finish, weight)
+	if err != nil {
+		return nil, 0, err
+	}
+	if d, ok := dist[finish]; ok {
+		return tracePath(prev, start, finish), d, nil
+	}
+	return nil, 0, NotFoundError{fmt.Sprintf("No route exists from %v to %v", start, finish)}
+}
+
+// dijkstra returns the least-cost distance from start to each Node it
+// settles, measuring edges with w, and the predecessor of each Node along its
+// path. It stops once finish is settled; a nil finish settles every Node
+// reachable from start.
+func (g *IntGraph) dijkstra(start, finish Node, w func(from, to Node) float64) (map[Node]float64, map[Node]Node, error) {
+	dist := map[Node]float64{start: 0}
+	prev := map[Node]Node{}
+	settled := map[Node]float64{}
+	pq := newNodeHeap(start, 0)
+	for pq.Len() > 0 {
+		curr := pq.pop()
+		if _, ok := settled[curr.node]; ok {
+			continue
+		}
+		settled[curr.node] = curr.priority
+		if curr.node == finish {
+			break
+		}
+		for _, nbr := range g.adjacent(curr.node) {
+			wt := w(curr.node, nbr)
+			if wt < 0 {
+				return nil, nil, fmt.Errorf("Dijkstra requires non-negative weights: %v->%v has weight %v", curr.node, nbr, wt)
+			}
+			d := curr.priority + wt
+			if old, ok := dist[nbr]; !ok || d < old {
+				dist[nbr] = d
+				prev[nbr] = curr.node
+				pq.push(nbr, d)
+			}
+		}
+	}
+	return settled, prev, nil
+}
+
+// ShortestPaths holds the result of a single-source shortest path search: the
+// distance from Source to each reachable Node, and the predecessor of each
+// Node along its shortest path.
+type ShortestPaths struct {
+	Source Node
+	Dist   map[Node]float64
+	Prev   map[Node]Node
+}
+
+// PathTo returns the shortest path from sp.Source to node, along with its
+// total cost.
+func (sp *ShortestPaths) PathTo(node Node) ([]Node, float64, error) {
+	d, ok := sp.Dist[node]
+	if !ok {
+		return nil, 0, NotFoundError{fmt.Sprintf("No route exists from %v to %v", sp.Source, node)}
+	}
+	return tracePath(sp.Prev, sp.Source, node), d, nil
+}
+
+// BellmanFord computes shortest paths from source to every reachable Node,
+// tolerating negative edge weights. If a negative-weight cycle is reachable
+// from source, it returns a NegativeCycleError listing the cycle.
+func (g *IntGraph) BellmanFord(source Node) (*ShortestPaths, error) {
+	if !g.HasNode(source) {
+		return nil, MissingNodeError{g, source}
+	}
+	nodes := g.nodeList()
+	sp := &ShortestPaths{
+		Source: source,
+		Dist:   map[Node]float64{source: 0},
+		Prev:   map[Node]Node{},
+	}
+	relax := func() Node {
+		var relaxed Node
+		for _, u := range nodes {
+			du, ok := sp.Dist[u]
+			if !ok {
+				continue
+			}
+			for _, v := range g.adjacent(u) {
+				d := du + weight(u, v)
+				if old, ok := sp.Dist[v]; !ok || d < old {
+					sp.Dist[v] = d
+					sp.Prev[v] = u
+					relaxed = v
+				}
+			}
+		}
+		return relaxed
+	}
+	for i := 1; i < len(nodes); i++ {
+		if relax() == nil {
+			return sp, nil
+		}
+	}
+	if v := relax(); v != nil {
+		return nil, NegativeCycleError{negativeCycle(sp.Prev, v, len(nodes))}
+	}
+	return sp, nil
+}
+
+// negativeCycle returns the negative-weight cycle found by following prev
+// links back from v, a Node still relaxed after n-1 rounds of Bellman-Ford in
+// a graph of n Nodes.
+func negativeCycle(prev map[Node]Node, v Node, n int) []Node {
+	// v may hang off the cycle rather than sit on it; walking back n
+	// predecessors is guaranteed to land inside the cycle.
+	for i := 0; i < n; i++ {
+		v = prev[v]
+	}
+	cycle := []Node{v}
+	for u := prev[v]; u != v; u = prev[u] {
+		cycle = append(cycle, u)
+	}
+	cycle = append(cycle, v)
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
+
+// Heuristic estimates the cost of the cheapest path from node to goal. For
+// AStar to return an optimal path, it must never overestimate that cost. A
+// heuristic that is also consistent, never dropping by more than the weight of
+// an edge, lets AStar expand each Node at most once.
+type Heuristic func(node, goal Node) float64
+
+// AStar returns the least-cost path from start to goal, along with its total
+// cost, expanding Nodes in order of known cost plus the heuristic estimate. If
+// sf is not nil, it is applied to each Node as it is expanded; returning
+// done=true halts the search before the goal is reached. A Node is expanded
+// again if a cheaper path to it is found after it was expanded, which can
+// happen when the heuristic is not consistent. Edge weights must be
+// non-negative.
+func (g *IntGraph) AStar(start, goal Node, h Heuristic, sf SearchFunc) ([]Node, float64, error) {
+	if !g.HasNode(start) {
+		return nil, 0, MissingNodeError{g, start}
+	}
+	if !g.HasNode(goal) {
+		return nil, 0, MissingNodeError{g, goal}
+	}
+	dist := map[Node]float64{start: 0}
+	prev := map[Node]Node{}
+	closed := map[Node]struct{}{}
+	pq := newNodeHeap(start, h(start, goal))
+	for pq.Len() > 0 {
+		curr := pq.pop().node
+		if _, ok := closed[curr]; ok {
+			continue
+		}
+		closed[curr] = struct{}{}
+		if sf != nil {
+			if _, done := sf(curr); done {
+				return nil, 0, NotFoundError{"Search halted: goal not reached"}
+			}
+		}
+		if curr == goal {
+			return tracePath(prev, start, goal), dist[goal], nil
+		}
+		for _, nbr := range g.adjacent(curr) {
+			wt := weight(curr, nbr)
+			if wt < 0 {
+				return nil, 0, fmt.Errorf("AStar requires non-negative weights: %v->%v has weight %v", curr, nbr, wt)
+			}
+			d := dist[curr] + wt
+			if old, ok := dist[nbr]; !ok || d < old {
+				delete(closed, nbr)
+				dist[nbr] = d
+				prev[nbr] = curr
+				pq.push(nbr, d+h(nbr, goal))
+			}
+		}
+	}
+	return nil, 0, NotFoundError{fmt.Sprintf("No route exists from %v to %v", start, goal)}
+}
@@ -0,0 +1,328 @@
+package graph
+
+import "testing"
+
+type weightedEdge struct {
+	to     int
+	weight float64
+}
+
+// newWeightedIntGraph builds an IntGraph with one IntNode per value in nodes,
+// connecting nodes[i] to each of edges[i].
+func newWeightedIntGraph(nodes []int, edges [][]weightedEdge) (*IntGraph, []*IntNode) {
+	ns := make([]*IntNode, len(nodes))
+	g := NewIntGraph()
+	for i, n := range nodes {
+		ns[i] = NewIntNode(n)
+		g.Insert(ns[i])
+	}
+	for i, es := range edges {
+		for _, e := range es {
+			ns[i].AddEdge(ns[e.to], e.weight, nil)
+		}
+	}
+	return g, ns
+}
+
+// pathValues converts a path of Nodes to their int values
+func pathValues(path []Node) []int {
+	values := make([]int, len(path))
+	for i, n := range path {
+		values[i] = n.Value().(int)
+	}
+	return values
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCycle returns true if closed walks a and b visit the same nodes in the
+// same order, regardless of where each starts.
+func sameCycle(a, b []int) bool {
+	if len(a) != len(b) || len(a) == 0 || a[0] != a[len(a)-1] || b[0] != b[len(b)-1] {
+		return false
+	}
+	n := len(a) - 1
+	for offset := 0; offset < n; offset++ {
+		match := true
+		for i := 0; i < n && match; i++ {
+			match = a[(i+offset)%n] == b[i]
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+var shortestPathTests = []struct {
+	nodes  []int
+	edges  [][]weightedEdge
+	start  int
+	finish int
+	path   []int
+	cost   float64
+	err    bool
+}{
+	{
+		[]int{0, 1, 2},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1}, {2, 5}},
+			[]weightedEdge{{2, 1}},
+			[]weightedEdge{},
+		},
+		0,
+		2,
+		[]int{0, 1, 2},
+		2,
+		false,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 4}, {2, 1}},
+			[]weightedEdge{{3, 1}},
+			[]weightedEdge{{1, 2}, {3, 5}},
+			[]weightedEdge{{4, 3}},
+			[]weightedEdge{},
+		},
+		0,
+		4,
+		[]int{0, 2, 1, 3, 4},
+		7,
+		false,
+	},
+	{
+		[]int{0, 1, 2},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1}},
+			[]weightedEdge{{0, 1}},
+			[]weightedEdge{{0, 1}},
+		},
+		0,
+		2,
+		nil,
+		0,
+		true,
+	},
+	{
+		[]int{0},
+		[][]weightedEdge{
+			[]weightedEdge{},
+		},
+		0,
+		0,
+		[]int{0},
+		0,
+		false,
+	},
+}
+
+func TestShortestPath(t *testing.T) {
+	for _, tt := range shortestPathTests {
+		g, nodes := newWeightedIntGraph(tt.nodes, tt.edges)
+		path, cost, err := g.ShortestPath(nodes[tt.start], nodes[tt.finish])
+		if tt.err {
+			if _, ok := err.(NotFoundError); !ok {
+				t.Errorf("ShortestPath: expected NotFoundError, actual %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if act := pathValues(path); !equalInts(act, tt.path) || cost != tt.cost {
+			t.Errorf("ShortestPath: expected %v (%v), actual %v (%v)", tt.path, tt.cost, act, cost)
+		}
+	}
+}
+
+func TestShortestPathMissingNode(t *testing.T) {
+	g, nodes := newWeightedIntGraph([]int{0}, [][]weightedEdge{{}})
+	if _, _, err := g.ShortestPath(nodes[0], NewIntNode(1)); err == nil {
+		t.Errorf("ShortestPath: expected MissingNodeError, actual nil")
+	} else if _, ok := err.(MissingNodeError); !ok {
+		t.Errorf("ShortestPath: expected MissingNodeError, actual %v", err)
+	}
+}
+
+func TestEdgeMeta(t *testing.T) {
+	a, b := NewIntNode(0), NewIntNode(1)
+	a.AddNeighbor(b)
+	if e, ok := a.Edge(b); !ok || e.Weight != 1 {
+		t.Errorf("Edge: expected weight 1, actual %v", e.Weight)
+	}
+	a.AddEdge(b, 2.5, "fiber")
+	if e, ok := a.Edge(b); !ok || e.Weight != 2.5 || e.Meta != "fiber" {
+		t.Errorf("Edge: expected 2.5 (fiber), actual %v (%v)", e.Weight, e.Meta)
+	}
+	a.RemoveNeighbor(b)
+	if _, ok := a.Edge(b); ok {
+		t.Errorf("Edge: expected no edge after RemoveNeighbor")
+	}
+}
+
+var bellmanFordTests = []struct {
+	nodes []int
+	edges [][]weightedEdge
+	dist  []float64
+	cycle []int
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 4}, {2, 5}},
+			[]weightedEdge{{3, 3}},
+			[]weightedEdge{{1, -3}},
+			[]weightedEdge{},
+		},
+		[]float64{0, 2, 5, 5},
+		nil,
+	},
+	{
+		[]int{0, 1, 2, 3},
+		[][]weightedEdge{
+			[]weightedEdge{{1, 1}},
+			[]weightedEdge{{2, 1}},
+			[]weightedEdge{{3, -2}},
+			[]weightedEdge{{1, -1}},
+		},
+		nil,
+		[]int{1, 2, 3, 1},
+	},
+}
+
+func TestBellmanFord(t *testing.T) {
+	for _, tt := range bellmanFordTests {
+		g, nodes := newWeightedIntGraph(tt.nodes, tt.edges)
+		sp, err := g.BellmanFord(nodes[0])
+		if tt.cycle != nil {
+			nce, ok := err.(NegativeCycleError)
+			if !ok {
+				t.Errorf("BellmanFord: expected NegativeCycleError, actual %v", err)
+				continue
+			}
+			// The cycle may start at any of its nodes
+			act := pathValues(nce.Cycle)
+			if !sameCycle(act, tt.cycle) {
+				t.Errorf("BellmanFord: expected cycle %v, actual %v", tt.cycle, act)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		for i, d := range tt.dist {
+			if _, cost, _ := sp.PathTo(nodes[i]); cost != d {
+				t.Errorf("BellmanFord: expected dist(%v) = %v, actual %v", i, d, cost)
+			}
+		}
+	}
+}
+
+func manhattan(w int) Heuristic {
+	abs := func(a int) int {
+		if a < 0 {
+			return -a
+		}
+		return a
+	}
+	return func(node, goal Node) float64 {
+		a, b := node.Value().(int), goal.Value().(int)
+		return float64(abs(a%w-b%w) + abs(a/w-b/w))
+	}
+}
+
+var aStarTests = []struct {
+	w, h   int
+	walls  []int
+	start  int
+	goal   int
+	cost   float64
+	err    bool
+	maxExp int
+}{
+	// A straight corridor should expand only the cells along the way
+	{5, 1, nil, 0, 4, 4, false, 5},
+	{5, 5, []int{2, 7, 12, 17}, 0, 4, 12, false, 25},
+	{3, 3, []int{1, 4, 7}, 0, 2, 0, true, 9},
+}
+
+func TestAStar(t *testing.T) {
+	for _, tt := range aStarTests {
+		g := Grid(tt.h, tt.w)
+		for _, c := range tt.walls {
+			wall, _ := g.Get(c)
+			g.Remove(wall)
+		}
+		start, _ := g.Get(tt.start)
+		goal, _ := g.Get(tt.goal)
+		expanded := []int{}
+		path, cost, err := g.AStar(start, goal, manhattan(tt.w), func(n Node) (interface{}, bool) {
+			expanded = append(expanded, n.Value().(int))
+			return nil, false
+		})
+		if len(expanded) > tt.maxExp || expanded[0] != tt.start {
+			t.Errorf("AStar: expected at most %v expansions from %v, actual %v", tt.maxExp, tt.start, expanded)
+		}
+		if tt.err {
+			if _, ok := err.(NotFoundError); !ok {
+				t.Errorf("AStar: expected NotFoundError, actual %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if cost != tt.cost || len(path) != int(tt.cost)+1 {
+			t.Errorf("AStar: expected cost %v, actual %v via %v", tt.cost, cost, pathValues(path))
+		}
+	}
+}
+
+func TestAStarInconsistent(t *testing.T) {
+	// S=0, A=1, B=2, G=3: h(B)=5 never overestimates, but is not consistent,
+	// so A is first expanded via S->A at 4, then reached via B at 2
+	g, nodes := newWeightedIntGraph([]int{0, 1, 2, 3}, [][]weightedEdge{
+		{{1, 4}, {2, 1}},
+		{{3, 5}},
+		{{1, 1}},
+		{},
+	})
+	h := func(node, goal Node) float64 {
+		if node == nodes[2] {
+			return 5
+		}
+		return 0
+	}
+	path, cost, err := g.AStar(nodes[0], nodes[3], h, nil)
+	if err != nil || cost != 7 || !equalInts(pathValues(path), []int{0, 2, 1, 3}) {
+		t.Errorf("AStar: expected [0 2 1 3] at 7, actual %v at %v (%v)", pathValues(path), cost, err)
+	}
+}
+
+func TestAStarHalt(t *testing.T) {
+	g := Grid(5, 5)
+	start, _ := g.Get(0)
+	goal, _ := g.Get(24)
+	visits := 0
+	_, _, err := g.AStar(start, goal, manhattan(5), func(n Node) (interface{}, bool) {
+		visits++
+		return nil, visits == 3
+	})
+	if _, ok := err.(NotFoundError); !ok || visits != 3 {
+		t.Errorf("AStar: expected halt after 3 expansions, actual %v (%v)", visits, err)
+	}
+}
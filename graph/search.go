@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// DFSContext executes a depth-first search as DFS does, but stops as soon as
+// ctx is cancelled or its deadline passes, returning ctx.Err(). The search can
+// be bounded with the MaxDepth and MaxVisits options; if it ends without
+// reaching the objective while Nodes were left unvisited because of them, it
+// returns a NotFoundError saying the budget was exhausted.
+func (g *IntGraph) DFSContext(ctx context.Context, node Node, sf SearchFunc, opts ...TraversalOption) (interface{}, error) {
+	if !g.HasNode(node) {
+		return nil, MissingNodeError{g, node}
+	}
+	t := newTraversal(g, opts)
+	return search(ctx, g.preOrder(node, t), t, sf)
+}
+
+// BFSContext executes a breadth-first search as BFS does, but stops as soon as
+// ctx is cancelled or its deadline passes, returning ctx.Err(). The search can
+// be bounded with the MaxDepth and MaxVisits options, as for DFSContext.
+func (g *IntGraph) BFSContext(ctx context.Context, node Node, sf SearchFunc, opts ...TraversalOption) (interface{}, error) {
+	if !g.HasNode(node) {
+		return nil, MissingNodeError{g, node}
+	}
+	t := newTraversal(g, opts)
+	return search(ctx, g.breadthFirst(node, t), t, sf)
+}
+
+// RouteExistsContext returns true if a route from start to finish exists, as
+// RouteExists does, but stops as soon as ctx is cancelled or its deadline
+// passes, returning ctx.Err(). If the MaxDepth or MaxVisits options stop the
+// search before it finds finish, the answer is unknown, and it returns false
+// along with a NotFoundError saying the budget was exhausted.
+func (g *IntGraph) RouteExistsContext(ctx context.Context, start, finish Node, opts ...TraversalOption) (bool, error) {
+	if !g.HasNode(start) || !g.HasNode(finish) {
+		return false, nil
+	}
+	_, err := g.BFSContext(ctx, start, func(node Node) (interface{}, bool) {
+		return nil, node == finish
+	}, opts...)
+	if err == nil {
+		return true, nil
+	}
+	if nfe, ok := err.(NotFoundError); ok && nfe == exhausted {
+		return false, nil
+	}
+	return false, err
+}
+
+var (
+	exhausted       = NotFoundError{"Search exhausted graph: objective not found"}
+	budgetExhausted = NotFoundError{"Search budget exhausted: objective not found"}
+)
+
+// search applies sf to each Node of the traversal nodes, checking ctx before
+// each visit.
+func search(ctx context.Context, nodes iter.Seq2[int, Node], t *traversal, sf SearchFunc) (interface{}, error) {
+	for _, n := range nodes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if value, done := sf(n); done {
+			return value, nil
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if t.limited {
+		return nil, budgetExhausted
+	}
+	return nil, exhausted
+}
+
+// ShortestRoute returns a path from start to finish with the fewest edges,
+// using a bidirectional breadth-first search: it alternately expands a level
+// forward from start and a level backward from finish, along incoming edges,
+// until the two searches meet. On large sparse graphs this visits far fewer
+// Nodes than a search from start alone. Incoming edges are found quickly for
+// a ReverseNode, such as an IntNode, and by scanning the graph otherwise.
+func (g *IntGraph) ShortestRoute(start, finish Node) ([]Node, error) {
+	if !g.HasNode(start) {
+		return nil, MissingNodeError{g, start}
+	}
+	if !g.HasNode(finish) {
+		return nil, MissingNodeError{g, finish}
+	}
+	if start == finish {
+		return []Node{start}, nil
+	}
+	// prev links each Node found forward to its predecessor on the way from
+	// start; next links each Node found backward to its successor on the way
+	// to finish
+	prev := map[Node]Node{start: nil}
+	next := map[Node]Node{finish: nil}
+	distF := map[Node]int{start: 0}
+	distB := map[Node]int{finish: 0}
+	forward, backward := []Node{start}, []Node{finish}
+	for len(forward) > 0 && len(backward) > 0 {
+		var meet Node
+		best := -1
+		if len(forward) <= len(backward) {
+			level := []Node{}
+			for _, n := range forward {
+				for _, nbr := range g.adjacent(n) {
+					if _, ok := distF[nbr]; ok || !g.HasNode(nbr) {
+						continue
+					}
+					distF[nbr], prev[nbr] = distF[n]+1, n
+					level = append(level, nbr)
+					if d, ok := distB[nbr]; ok && (best < 0 || distF[nbr]+d < best) {
+						meet, best = nbr, distF[nbr]+d
+					}
+				}
+			}
+			forward = level
+		} else {
+			level := []Node{}
+			for _, n := range backward {
+				for _, p := range g.predecessors(n) {
+					if _, ok := distB[p]; ok {
+						continue
+					}
+					distB[p], next[p] = distB[n]+1, n
+					level = append(level, p)
+					if d, ok := distF[p]; ok && (best < 0 || distB[p]+d < best) {
+						meet, best = p, distB[p]+d
+					}
+				}
+			}
+			backward = level
+		}
+		if best >= 0 {
+			path := tracePath(prev, start, meet)
+			for n := next[meet]; n != nil; n = next[n] {
+				path = append(path, n)
+			}
+			return path, nil
+		}
+	}
+	return nil, NotFoundError{fmt.Sprintf("No route exists from %v to %v", start, finish)}
+}
+
+// RouteExistsBidirectional returns true if a route from start to finish
+// exists, searching from both ends at once as ShortestRoute does.
+func (g *IntGraph) RouteExistsBidirectional(start, finish Node) bool {
+	_, err := g.ShortestRoute(start, finish)
+	return err == nil
+}
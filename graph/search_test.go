@@ -0,0 +1,231 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newChainIntGraph builds an IntGraph of n IntNodes, each connected to the
+// next.
+func newChainIntGraph(n int) (*IntGraph, []*IntNode) {
+	edges := make([][]int, n)
+	values := make([]int, n)
+	for i := range values {
+		values[i] = i
+		if i+1 < n {
+			edges[i] = []int{i + 1}
+		}
+	}
+	return newIntGraph(values, edges)
+}
+
+func TestSearchContextCancel(t *testing.T) {
+	g, nodes := newChainIntGraph(100)
+	for name, search := range map[string]func(context.Context, Node, SearchFunc, ...TraversalOption) (interface{}, error){
+		"DFSContext": g.DFSContext,
+		"BFSContext": g.BFSContext,
+	} {
+		ctx, cancel := context.WithCancel(context.Background())
+		visits := 0
+		_, err := search(ctx, nodes[0], func(n Node) (interface{}, bool) {
+			visits++
+			if visits == 10 {
+				cancel()
+			}
+			return nil, false
+		})
+		if err != context.Canceled || visits != 10 {
+			t.Errorf("%s: expected context.Canceled after 10 visits, actual %v after %v", name, err, visits)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+		_, err = search(ctx, nodes[0], func(n Node) (interface{}, bool) {
+			time.Sleep(time.Millisecond)
+			return nil, false
+		})
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Errorf("%s: expected context.DeadlineExceeded, actual %v", name, err)
+		}
+	}
+}
+
+// budgetTests search from 0 in a chain of 10 IntNodes, unless edges is set
+var budgetTests = []struct {
+	edges  [][]int
+	opts   []TraversalOption
+	finish int
+	exists bool
+	err    error
+}{
+	{nil, nil, 9, true, nil},
+	{nil, []TraversalOption{MaxDepth(9)}, 9, true, nil},
+	{nil, []TraversalOption{MaxDepth(8)}, 9, false, budgetExhausted},
+	{nil, []TraversalOption{MaxVisits(10)}, 9, true, nil},
+	{nil, []TraversalOption{MaxVisits(9)}, 9, false, budgetExhausted},
+	{nil, []TraversalOption{MaxDepth(3)}, 3, true, nil},
+	// DFS first reaches 2 via 1 at the depth limit, then directly from 0
+	{[][]int{{1, 2}, {2}, {3}, {}}, []TraversalOption{MaxDepth(2)}, 3, true, nil},
+	{[][]int{{1, 2}, {2}, {3}, {}}, []TraversalOption{MaxDepth(1)}, 3, false, budgetExhausted},
+	// 1 is at the depth limit, but its only neighbor was already reached
+	{[][]int{{1}, {0}, {}, {}}, []TraversalOption{MaxDepth(1)}, 2, false, nil},
+}
+
+func TestRouteExistsContextBudget(t *testing.T) {
+	for _, tt := range budgetTests {
+		g, nodes := newChainIntGraph(10)
+		if tt.edges != nil {
+			g, nodes = newIntGraph([]int{0, 1, 2, 3}, tt.edges)
+			g.OrderByInsertion()
+		}
+		exists, err := g.RouteExistsContext(context.Background(), nodes[0], nodes[tt.finish], tt.opts...)
+		if exists != tt.exists || err != tt.err {
+			t.Errorf("RouteExistsContext: expected %v (%v), actual %v (%v)", tt.exists, tt.err, exists, err)
+		}
+		finish := func(n Node) (interface{}, bool) { return n, n == nodes[tt.finish] }
+		exp := tt.err
+		if !tt.exists && exp == nil {
+			exp = exhausted
+		}
+		_, err = g.DFSContext(context.Background(), nodes[0], finish, tt.opts...)
+		if (err == nil) != tt.exists || (!tt.exists && err != exp) {
+			t.Errorf("DFSContext: expected found=%v (%v), actual %v", tt.exists, exp, err)
+		}
+		count, found := 0, false
+		for n := range g.PostOrder(nodes[0], tt.opts...) {
+			count++
+			found = found || n == nodes[tt.finish]
+		}
+		if found != tt.exists {
+			t.Errorf("PostOrder: expected found=%v, actual %v after %v nodes", tt.exists, found, count)
+		}
+	}
+	// Exhausting the whole graph within budget is a definite answer
+	g, nodes := newChainIntGraph(10)
+	if exists, err := g.RouteExistsContext(context.Background(), nodes[9], nodes[0], MaxVisits(5)); exists || err != nil {
+		t.Errorf("RouteExistsContext: expected false (nil), actual %v (%v)", exists, err)
+	}
+}
+
+func TestTraversalBudget(t *testing.T) {
+	g, nodes := newChainIntGraph(10)
+	count := 0
+	for range g.PostOrder(nodes[0], MaxDepth(4)) {
+		count++
+	}
+	if count != 5 {
+		t.Errorf("PostOrder: expected 5 nodes within depth 4, actual %v", count)
+	}
+	count = 0
+	for range g.PreOrder(nodes[0], MaxVisits(3)) {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("PreOrder: expected 3 visits, actual %v", count)
+	}
+}
+
+var shortestRouteTests = []struct {
+	nodes  []int
+	edges  [][]int
+	start  int
+	finish int
+	length int
+}{
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][]int{
+			[]int{1, 2},
+			[]int{3},
+			[]int{4},
+			[]int{5},
+			[]int{3},
+			[]int{},
+		},
+		0,
+		5,
+		4,
+	},
+	{
+		[]int{0, 1, 2, 3, 4, 5, 6},
+		[][]int{
+			[]int{1, 2, 3},
+			[]int{4},
+			[]int{1, 5},
+			[]int{2, 4, 5},
+			[]int{3},
+			[]int{1, 4, 6},
+			[]int{0},
+		},
+		6,
+		5,
+		4,
+	},
+	{
+		[]int{0, 1, 2},
+		[][]int{
+			[]int{1},
+			[]int{0},
+			[]int{0},
+		},
+		0,
+		2,
+		0,
+	},
+	{
+		[]int{0},
+		[][]int{
+			[]int{},
+		},
+		0,
+		0,
+		1,
+	},
+}
+
+func TestShortestRoute(t *testing.T) {
+	for _, tt := range shortestRouteTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		path, err := g.ShortestRoute(nodes[tt.start], nodes[tt.finish])
+		if exists := g.RouteExistsBidirectional(nodes[tt.start], nodes[tt.finish]); exists != (tt.length > 0) {
+			t.Errorf("RouteExistsBidirectional: expected %v, actual %v", tt.length > 0, exists)
+		}
+		if tt.length == 0 {
+			if _, ok := err.(NotFoundError); !ok {
+				t.Errorf("ShortestRoute: expected NotFoundError, actual %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if len(path) != tt.length || path[0] != nodes[tt.start] || path[len(path)-1] != nodes[tt.finish] {
+			t.Errorf("ShortestRoute: expected %v nodes from %v to %v, actual %v", tt.length, tt.start, tt.finish, pathValues(path))
+		}
+		for i := 1; i < len(path); i++ {
+			if !path[i-1].HasNeighbor(path[i]) {
+				t.Errorf("ShortestRoute: path %v uses missing edge", pathValues(path))
+			}
+		}
+	}
+}
+
+func TestShortestRouteRandom(t *testing.T) {
+	g := ErdosRenyi(500, 2.0/500, false, 7)
+	start, _ := g.Get(0)
+	for i := 1; i < 50; i++ {
+		finish, _ := g.Get(i)
+		exp := -1
+		for d, n := range g.BreadthFirst(start) {
+			if n == finish {
+				exp = d
+				break
+			}
+		}
+		path, err := g.ShortestRoute(start, finish)
+		if (exp < 0) != (err != nil) || (exp >= 0 && len(path) != exp+1) {
+			t.Errorf("ShortestRoute: expected %v edges to %v, actual %v (%v)", exp, finish, len(path)-1, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package graph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// frozenNode is a SnapshotNode and a ReverseNode. It holds the adjacency and
+// predecessors of its source Node as they were when the snapshot was taken,
+// keyed by live Nodes, and translates them to frozenNodes of the same snapshot
+// the first time they are read.
+type frozenNode struct {
+	snap   *IntGraph
+	source Node
+	value  interface{}
+
+	rawOrder []Node
+	rawEdges map[Node]Edge
+	rawPreds map[Node]struct{}
+
+	once      sync.Once
+	neighbors map[Node]struct{}
+	order     []Node
+	edges     map[Node]Edge
+	preds     map[Node]struct{}
+}
+
+// freeze returns the neighbors, edges and predecessors of node as they are
+// now, shared without copying if node is a freezer. The predecessors are nil
+// if node is not a ReverseNode.
+func freeze(node Node) ([]Node, map[Node]Edge, map[Node]struct{}) {
+	if f, ok := node.(freezer); ok {
+		return f.freeze()
+	}
+	var order []Node
+	if on, ok := node.(OrderedNode); ok {
+		order = on.OrderedNeighbors()
+	} else {
+		order = neighborList(node)
+	}
+	edges := map[Node]Edge{}
+	for _, nbr := range order {
+		if wn, ok := node.(WeightedNode); ok {
+			if e, ok := wn.Edge(nbr); ok {
+				edges[nbr] = e
+			}
+		}
+	}
+	var preds map[Node]struct{}
+	if rn, ok := node.(ReverseNode); ok {
+		preds = rn.Predecessors()
+	}
+	return order, edges, preds
+}
+
+// Snapshot returns a read-only IntGraph holding the Nodes and edges of g as
+// they are now. Every algorithm runs on a snapshot as on g, unaffected by later
+// changes to g, so that long analyses need not hold up writers.
+//
+// Taking a snapshot costs time proportional to the number of Nodes, not
+// edges: each IntNode shares its adjacency and predecessors with the snapshot,
+// and copies them only when it is next changed. Other Nodes have their
+// adjacency copied, and if they are not ReverseNodes, their predecessors found
+// by one pass over the edges of g. Edges to Nodes outside g are left out of
+// the snapshot.
+//
+// The snapshot is consistent with every edit made through g: an edge added
+// with g.AddNeighbor, both ways in an undirected graph, or a Node detached with
+// g.Remove, is in the snapshot whole or not at all. Edits made by calling a
+// Node's own methods, e.g. IntNode.AddEdge, bypass g and are frozen one Node at
+// a time, so a concurrent snapshot may hold an edge without its reverse or its
+// predecessor entry.
+//
+// The snapshot holds a SnapshotNode for each Node of g; InSnapshot finds the
+// one standing for a given Node. Changes to a snapshot are rejected with a
+// ReadOnlyError, or ignored where there is no error to return.
+func (g *IntGraph) Snapshot() *IntGraph {
+	if g.frozen != nil {
+		return g
+	}
+	// Edges added through g are added in both directions before a snapshot
+	// can be taken, and Nodes are not detached halfway.
+	g.edit.Lock()
+	defer g.edit.Unlock()
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	snap := &IntGraph{
+		nodes:      make(map[Node]int, len(g.nodes)),
+		seq:        g.seq,
+		undirected: g.undirected,
+		ordered:    g.ordered,
+		less:       g.less,
+		frozen:     make(map[Node]*frozenNode, len(g.nodes)),
+	}
+	untracked := false
+	for node, seq := range g.nodes {
+		fn := &frozenNode{snap: snap, source: node, value: node.Value()}
+		fn.rawOrder, fn.rawEdges, fn.rawPreds = freeze(node)
+		untracked = untracked || fn.rawPreds == nil
+		snap.nodes[fn] = seq
+		snap.frozen[node] = fn
+	}
+	if untracked {
+		snap.findPredecessors()
+	}
+	if g.index != nil {
+		snap.index = make(map[int]Node, len(g.index))
+		for id, node := range g.index {
+			snap.index[id] = snap.frozen[node]
+		}
+	}
+	return snap
+}
+
+// IsSnapshot returns true if g is a snapshot
+func (g *IntGraph) IsSnapshot() bool {
+	return g.frozen != nil
+}
+
+// InSnapshot returns the SnapshotNode standing for node in the snapshot g. It
+// returns false if g is not a snapshot, or node was not in the graph when g
+// was taken.
+func (g *IntGraph) InSnapshot(node Node) (SnapshotNode, bool) {
+	fn, ok := g.frozen[node]
+	return fn, ok
+}
+
+// findPredecessors fills in the predecessors of each frozenNode whose source
+// does not track them, from the frozen edges of every other.
+func (g *IntGraph) findPredecessors() {
+	for _, fn := range g.frozen {
+		for _, nbr := range fn.rawOrder {
+			if to, ok := g.frozen[nbr]; ok {
+				if _, ok := to.source.(ReverseNode); !ok {
+					if to.rawPreds == nil {
+						to.rawPreds = map[Node]struct{}{}
+					}
+					to.rawPreds[fn.source] = struct{}{}
+				}
+			}
+		}
+	}
+}
+
+// resolve translates fn's adjacency and predecessors from live Nodes to
+// frozenNodes
+func (fn *frozenNode) resolve() {
+	fn.once.Do(func() {
+		fn.neighbors = make(map[Node]struct{}, len(fn.rawOrder))
+		fn.edges = make(map[Node]Edge, len(fn.rawOrder))
+		fn.order = make([]Node, 0, len(fn.rawOrder))
+		for _, nbr := range fn.rawOrder {
+			// Empty places left by IntNode.RemoveNeighbor are skipped here
+			to, ok := fn.snap.frozen[nbr]
+			if !ok {
+				continue
+			}
+			fn.neighbors[to] = struct{}{}
+			fn.order = append(fn.order, to)
+			if e, ok := fn.rawEdges[nbr]; ok {
+				fn.edges[to] = Edge{From: fn, To: to, Weight: e.Weight, Meta: e.Meta}
+			}
+		}
+		fn.preds = make(map[Node]struct{}, len(fn.rawPreds))
+		for p := range fn.rawPreds {
+			if from, ok := fn.snap.frozen[p]; ok {
+				fn.preds[from] = struct{}{}
+			}
+		}
+		fn.rawOrder, fn.rawEdges, fn.rawPreds = nil, nil, nil
+	})
+}
+
+// String returns the string representation of fn's source Node
+func (fn *frozenNode) String() string {
+	return fmt.Sprintf("%v", fn.source)
+}
+
+// Value returns the value of fn's source Node
+func (fn *frozenNode) Value() interface{} {
+	return fn.value
+}
+
+// Source returns the live Node that fn was frozen from
+func (fn *frozenNode) Source() Node {
+	return fn.source
+}
+
+// Neighbors returns the set of fn's neighbors in the snapshot
+func (fn *frozenNode) Neighbors() map[Node]struct{} {
+	fn.resolve()
+	return fn.neighbors
+}
+
+// OrderedNeighbors returns fn's neighbors in the order its source had them
+func (fn *frozenNode) OrderedNeighbors() []Node {
+	fn.resolve()
+	return append([]Node{}, fn.order...)
+}
+
+// Predecessors returns the set of Nodes with an edge to fn in the snapshot
+func (fn *frozenNode) Predecessors() map[Node]struct{} {
+	fn.resolve()
+	return fn.preds
+}
+
+// HasNeighbor returns true if node is fn's neighbor in the snapshot
+func (fn *frozenNode) HasNeighbor(node Node) bool {
+	fn.resolve()
+	_, ok := fn.neighbors[node]
+	return ok
+}
+
+// Edge returns the edge from fn to node, if it exists
+func (fn *frozenNode) Edge(node Node) (Edge, bool) {
+	fn.resolve()
+	e, ok := fn.edges[node]
+	return e, ok
+}
+
+// AddNeighbor returns a ReadOnlyError
+func (fn *frozenNode) AddNeighbor(node Node) error {
+	return ReadOnlyError{fn}
+}
+
+// AddEdge returns a ReadOnlyError
+func (fn *frozenNode) AddEdge(node Node, weight float64, meta interface{}) error {
+	return ReadOnlyError{fn}
+}
+
+// RemoveNeighbor returns a ReadOnlyError
+func (fn *frozenNode) RemoveNeighbor(node Node) error {
+	return ReadOnlyError{fn}
+}
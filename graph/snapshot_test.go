@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	g, nodes := newWeightedIntGraph([]int{0, 1, 2, 3}, [][]weightedEdge{
+		{{1, 1}, {2, 5}},
+		{{2, 1}},
+		{{3, 1}},
+		{},
+	})
+	snap := g.Snapshot()
+	if !snap.IsSnapshot() || g.IsSnapshot() {
+		t.Errorf("IsSnapshot: expected only the snapshot to be one")
+	}
+	s0, _ := snap.InSnapshot(nodes[0])
+	s3, _ := snap.InSnapshot(nodes[3])
+	if s0.Source() != nodes[0] || s0.Value() != 0 {
+		t.Errorf("Source: expected %v, actual %v", nodes[0], s0.Source())
+	}
+
+	// Change g every way there is
+	nodes[0].AddEdge(nodes[2], 0.5, nil)
+	nodes[1].RemoveNeighbor(nodes[2])
+	g.Remove(nodes[3])
+	extra := NewIntNode(4)
+	g.Insert(extra)
+	g.AddNeighbor(nodes[2], extra)
+
+	if snap.Size() != 4 || g.Size() != 4 {
+		t.Errorf("Size: expected 4 and 4, actual %v and %v", snap.Size(), g.Size())
+	}
+	if _, ok := snap.InSnapshot(extra); ok {
+		t.Errorf("InSnapshot: expected no Node inserted after the snapshot")
+	}
+	path, dist, err := snap.ShortestPath(s0, s3)
+	if err != nil || dist != 3 || !equalInts(pathValues(path), []int{0, 1, 2, 3}) {
+		t.Errorf("ShortestPath: expected [0 1 2 3] at 3, actual %v at %v (%v)", pathValues(path), dist, err)
+	}
+	if e, _ := s0.Edge(path[2]); e.Weight != 5 || e.From != s0 {
+		t.Errorf("Edge: expected weight 5 from %v, actual %v", s0, e)
+	}
+	preds, _ := snap.Predecessors(path[2])
+	if _, ok := path[2].(ReverseNode).Predecessors()[path[1]]; !ok || len(preds) != 2 {
+		t.Errorf("Predecessors: expected [0 1], actual %v", pathValues(preds))
+	}
+	if in, _ := g.InDegree(nodes[2]); in != 1 {
+		t.Errorf("InDegree: expected 1 in g after RemoveNeighbor, actual %v", in)
+	}
+	if route, err := snap.ShortestRoute(s0, s3); err != nil || len(route) != 3 {
+		t.Errorf("ShortestRoute: expected [0 2 3], actual %v (%v)", pathValues(route), err)
+	}
+	if _, _, err := g.ShortestPath(nodes[0], nodes[3]); err == nil {
+		t.Errorf("ShortestPath: expected no route in g after Remove, actual nil")
+	}
+	// A later snapshot sees the changes
+	later := g.Snapshot()
+	l0, _ := later.InSnapshot(nodes[0])
+	l2, _ := later.InSnapshot(nodes[2])
+	if e, _ := l0.Edge(l2); e.Weight != 0.5 {
+		t.Errorf("Snapshot: expected the new weight 0.5, actual %v", e.Weight)
+	}
+	if later.Snapshot() != later {
+		t.Errorf("Snapshot: expected a snapshot of a snapshot to be itself")
+	}
+}
+
+func TestSnapshotReadOnly(t *testing.T) {
+	g := Grid(3, 3)
+	snap := g.Snapshot()
+	a, _ := snap.Get(0)
+	b, _ := snap.Get(8)
+	if err := snap.AddNeighbor(a, b); err == nil {
+		t.Errorf("AddNeighbor: expected ReadOnlyError, actual nil")
+	} else if _, ok := err.(ReadOnlyError); !ok {
+		t.Errorf("AddNeighbor: expected ReadOnlyError, actual %v", err)
+	}
+	if err := snap.RemoveNeighborByID(0, 1); err == nil {
+		t.Errorf("RemoveNeighborByID: expected ReadOnlyError, actual nil")
+	}
+	if err := snap.UnmarshalJSON([]byte(`{}`)); err == nil {
+		t.Errorf("UnmarshalJSON: expected ReadOnlyError, actual nil")
+	}
+	snap.Insert(NewIntNode(9))
+	snap.Remove(a)
+	if snap.Size() != 9 || !snap.HasNode(a) || snap.GetOrCreate(10) != nil {
+		t.Errorf("Snapshot: expected Insert, Remove and GetOrCreate to change nothing")
+	}
+	if n, err := snap.GetOrInsert(10); err == nil {
+		t.Errorf("GetOrInsert: expected ReadOnlyError, actual %v", n)
+	} else if _, ok := err.(ReadOnlyError); !ok {
+		t.Errorf("GetOrInsert: expected ReadOnlyError, actual %v", err)
+	}
+	if n, err := snap.GetOrInsert(0); err != nil || n != a {
+		t.Errorf("GetOrInsert: expected %v, actual %v (%v)", a, n, err)
+	}
+	if edgeCount(snap) != 12 {
+		t.Errorf("Snapshot: expected 12 edges, actual %v", edgeCount(snap))
+	}
+}
+
+func TestSnapshotAlgorithms(t *testing.T) {
+	g := ErdosRenyi(200, 0.01, false, 4)
+	g.OrderBy(ValueLess)
+	snap := g.Snapshot()
+	if act, exp := len(snap.StronglyConnectedComponents()), len(g.StronglyConnectedComponents()); act != exp {
+		t.Errorf("StronglyConnectedComponents: expected %v, actual %v", exp, act)
+	}
+	if act, exp := snap.String(), g.String(); act != exp {
+		t.Errorf("String: expected the snapshot to print as g")
+	}
+	dag := RandomDAG(50, 0.1, 4)
+	if _, err := dag.Snapshot().TopologicalSort(); err != nil {
+		t.Errorf("TopologicalSort: expected nil error, actual %v", err)
+	}
+}
+
+func TestSnapshotConcurrent(t *testing.T) {
+	g := NewIndexedUndirectedIntGraph()
+	for i := 0; i < 100; i++ {
+		g.GetOrCreate(i)
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < 100; i += 4 {
+				for j := i + 1; j < 100; j += 7 {
+					g.AddNeighborByID(i, j)
+					if j%3 == 0 {
+						g.RemoveNeighborByID(j, i)
+					}
+				}
+			}
+		}(w)
+	}
+	// Every snapshot taken mid-write is consistent: its undirected edges
+	// are whole, and it does not change as writes go on
+	for i := 0; i < 20; i++ {
+		snap := g.Snapshot()
+		edges := edgeCount(snap)
+		for _, n := range snap.Nodes() {
+			for nbr := range n.Neighbors() {
+				if !nbr.HasNeighbor(n) {
+					t.Fatalf("Snapshot: expected edge %v-%v both ways", n, nbr)
+				}
+			}
+			if act, exp := len(n.(ReverseNode).Predecessors()), len(n.Neighbors()); act != exp {
+				t.Fatalf("Predecessors: expected %v for %v, actual %v", exp, n, act)
+			}
+		}
+		if act := edgeCount(snap); act != edges {
+			t.Fatalf("Snapshot: expected %v edges, actual %v", edges, act)
+		}
+	}
+	wg.Wait()
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	g, _ := BarabasiAlbert(50000, 4, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Snapshot()
+	}
+}
@@ -0,0 +1,340 @@
+package graph
+
+import "fmt"
+
+// hamiltonianDPLimit is the largest number of Nodes for which HamiltonianPath
+// uses bitmask dynamic programming rather than backtracking.
+const hamiltonianDPLimit = 20
+
+// eulerArc is an edge in the multigraph walked by Hierholzer's algorithm. In
+// an undirected graph, both directions of an edge share one id.
+type eulerArc struct {
+	to int
+	id int
+}
+
+// eulerGraph returns the IntGraph's Nodes, their outgoing arcs, and the number
+// of edges. A self-loop of an undirected IntGraph is a pair of arcs sharing one
+// id, so that it adds 2 to the degree of its Node.
+func (g *IntGraph) eulerGraph() ([]Node, [][]eulerArc, int) {
+	nodes := g.nodeList()
+	index := make(map[Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+	arcs := make([][]eulerArc, len(nodes))
+	edges := 0
+	if g.undirected {
+		for _, e := range g.undirectedEdges(nodes) {
+			u, v := index[e.From], index[e.To]
+			arcs[u] = append(arcs[u], eulerArc{v, edges})
+			arcs[v] = append(arcs[v], eulerArc{u, edges})
+			edges++
+		}
+		for u, n := range nodes {
+			for _, nbr := range g.adjacent(n) {
+				if nbr == n {
+					arcs[u] = append(arcs[u], eulerArc{u, edges}, eulerArc{u, edges})
+					edges++
+				}
+			}
+		}
+		return nodes, arcs, edges
+	}
+	for u, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if v, ok := index[nbr]; ok {
+				arcs[u] = append(arcs[u], eulerArc{v, edges})
+				edges++
+			}
+		}
+	}
+	return nodes, arcs, edges
+}
+
+// eulerStart checks the degree conditions for an Eulerian path, or a circuit
+// if circuit is true, returning the index of a Node to start from, or -1 if
+// the graph has no edges.
+func (g *IntGraph) eulerStart(nodes []Node, arcs [][]eulerArc, circuit bool) (int, error) {
+	start := -1
+	for i := range nodes {
+		if len(arcs[i]) > 0 {
+			start = i
+			break
+		}
+	}
+	if g.undirected {
+		odd := []int{}
+		for i := range nodes {
+			if len(arcs[i])%2 == 1 {
+				odd = append(odd, i)
+			}
+		}
+		if len(odd) == 0 {
+			return start, nil
+		}
+		if circuit || len(odd) != 2 {
+			msg := "Eulerian path requires 0 or 2 nodes of odd degree"
+			if circuit {
+				msg = "Eulerian circuit requires every node to have even degree"
+			}
+			return -1, DegreeError{msg, indexedNodes(nodes, odd)}
+		}
+		return odd[0], nil
+	}
+	in := make([]int, len(nodes))
+	for _, as := range arcs {
+		for _, a := range as {
+			in[a.to]++
+		}
+	}
+	heads, tails, unbalanced := []int{}, []int{}, []int{}
+	for i := range nodes {
+		switch out := len(arcs[i]); {
+		case out == in[i]:
+		case out == in[i]+1:
+			heads = append(heads, i)
+		case in[i] == out+1:
+			tails = append(tails, i)
+		default:
+			unbalanced = append(unbalanced, i)
+		}
+	}
+	if len(heads) == 0 && len(tails) == 0 && len(unbalanced) == 0 {
+		return start, nil
+	}
+	if circuit {
+		return -1, DegreeError{"Eulerian circuit requires every node to have equal in- and out-degree", indexedNodes(nodes, append(append(heads, tails...), unbalanced...))}
+	}
+	if len(unbalanced) > 0 {
+		return -1, DegreeError{"Eulerian path requires in- and out-degree to differ by at most 1", indexedNodes(nodes, unbalanced)}
+	}
+	if len(heads) != 1 || len(tails) != 1 {
+		return -1, DegreeError{"Eulerian path requires exactly one node with an extra out-edge and one with an extra in-edge", indexedNodes(nodes, append(heads, tails...))}
+	}
+	return heads[0], nil
+}
+
+func indexedNodes(nodes []Node, indices []int) []Node {
+	ns := make([]Node, len(indices))
+	for i, idx := range indices {
+		ns[i] = nodes[idx]
+	}
+	return ns
+}
+
+// eulerian walks every edge exactly once with Hierholzer's algorithm
+func (g *IntGraph) eulerian(circuit bool) ([]Node, error) {
+	nodes, arcs, edges := g.eulerGraph()
+	start, err := g.eulerStart(nodes, arcs, circuit)
+	if err != nil || start < 0 {
+		return []Node{}, err
+	}
+	used := make([]bool, edges)
+	next := make([]int, len(nodes))
+	stack := []int{start}
+	walk := []int{}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		for next[u] < len(arcs[u]) && used[arcs[u][next[u]].id] {
+			next[u]++
+		}
+		if next[u] == len(arcs[u]) {
+			walk = append(walk, u)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		a := arcs[u][next[u]]
+		used[a.id] = true
+		stack = append(stack, a.to)
+	}
+	if len(walk) != edges+1 {
+		return nil, NotFoundError{fmt.Sprintf("No Eulerian path exists: only %d of %d edges are connected to %v", len(walk)-1, edges, nodes[start])}
+	}
+	path := make([]Node, len(walk))
+	for i, idx := range walk {
+		path[len(walk)-1-i] = nodes[idx]
+	}
+	return path, nil
+}
+
+// EulerianPath returns a walk through the IntGraph that uses every edge
+// exactly once, found with Hierholzer's algorithm. If an Eulerian circuit
+// exists, the walk returned is one, starting and ending at the same Node. If
+// the Node degrees rule a path out, it returns a DegreeError naming the
+// offending Nodes; if the edges are not all connected, a NotFoundError.
+func (g *IntGraph) EulerianPath() ([]Node, error) {
+	return g.eulerian(false)
+}
+
+// EulerianCircuit returns a closed walk through the IntGraph that uses every
+// edge exactly once, starting and ending at the same Node. It returns a
+// DegreeError unless every Node has even degree in an undirected IntGraph, or
+// equal in- and out-degree in a directed one.
+func (g *IntGraph) EulerianCircuit() ([]Node, error) {
+	return g.eulerian(true)
+}
+
+// HamiltonianPath returns a path that visits every Node of the IntGraph
+// exactly once. Graphs of up to 20 Nodes are solved by dynamic programming
+// over subsets of Nodes, in O(2^n n^2) time; larger graphs by backtracking
+// search. Graphs whose degrees make a path impossible are rejected up front
+// with a DegreeError; otherwise, if no path exists, it returns a NotFoundError.
+func (g *IntGraph) HamiltonianPath() ([]Node, error) {
+	nodes := g.nodeList()
+	if len(nodes) == 0 {
+		return []Node{}, nil
+	}
+	index := make(map[Node]int, len(nodes))
+	for i, n := range nodes {
+		index[n] = i
+	}
+	adj := make([][]int, len(nodes))
+	in := make([]int, len(nodes))
+	for u, n := range nodes {
+		for _, nbr := range g.adjacent(n) {
+			if v, ok := index[nbr]; ok && v != u {
+				adj[u] = append(adj[u], v)
+				in[v]++
+			}
+		}
+	}
+	if err := hamiltonianDegrees(nodes, adj, in, g.undirected); err != nil {
+		return nil, err
+	}
+	var path []int
+	if len(nodes) <= hamiltonianDPLimit {
+		path = hamiltonianDP(adj)
+	} else {
+		path = hamiltonianBacktrack(adj)
+	}
+	if path == nil {
+		return nil, NotFoundError{"No Hamiltonian path exists"}
+	}
+	return indexedNodes(nodes, path), nil
+}
+
+// hamiltonianDegrees rejects graphs where too many Nodes could only be an end
+// of the path: every Node but the first needs an edge in, and every Node but
+// the last needs an edge out.
+func hamiltonianDegrees(nodes []Node, adj [][]int, in []int, undirected bool) error {
+	if len(nodes) == 1 {
+		return nil
+	}
+	noIn, noOut, leaves := []int{}, []int{}, []int{}
+	for i := range nodes {
+		if in[i] == 0 {
+			noIn = append(noIn, i)
+		}
+		if len(adj[i]) == 0 {
+			noOut = append(noOut, i)
+		}
+		if len(adj[i]) <= 1 {
+			leaves = append(leaves, i)
+		}
+	}
+	if undirected {
+		if len(noOut) > 0 {
+			return DegreeError{"Hamiltonian path requires every node to have an edge", indexedNodes(nodes, noOut)}
+		}
+		if len(leaves) > 2 {
+			return DegreeError{"Hamiltonian path allows at most 2 nodes of degree 1", indexedNodes(nodes, leaves)}
+		}
+		return nil
+	}
+	if len(noIn) > 1 {
+		return DegreeError{"Hamiltonian path allows at most 1 node without incoming edges", indexedNodes(nodes, noIn)}
+	}
+	if len(noOut) > 1 {
+		return DegreeError{"Hamiltonian path allows at most 1 node without outgoing edges", indexedNodes(nodes, noOut)}
+	}
+	return nil
+}
+
+// hamiltonianDP finds a Hamiltonian path where reach[mask] holds, as a bitmask,
+// the Nodes at which some path visiting exactly the Nodes in mask can end.
+func hamiltonianDP(adj [][]int) []int {
+	n := len(adj)
+	full := 1<<n - 1
+	reach := make([]uint32, 1<<n)
+	for v := 0; v < n; v++ {
+		reach[1<<v] = 1 << v
+	}
+	for mask := 1; mask <= full; mask++ {
+		for u := 0; u < n; u++ {
+			if reach[mask]&(1<<u) == 0 {
+				continue
+			}
+			for _, v := range adj[u] {
+				if mask&(1<<v) == 0 {
+					reach[mask|1<<v] |= 1 << v
+				}
+			}
+		}
+	}
+	if reach[full] == 0 {
+		return nil
+	}
+	// Walk back from any end, finding a predecessor that could end the path
+	// through the remaining Nodes
+	path := make([]int, n)
+	mask := full
+	end := 0
+	for reach[full]&(1<<end) == 0 {
+		end++
+	}
+	for i := n - 1; i >= 0; i-- {
+		path[i] = end
+		prev := mask &^ (1 << end)
+		if prev == 0 {
+			break
+		}
+		for u := 0; u < n; u++ {
+			if reach[prev]&(1<<u) != 0 && hasArc(adj[u], end) {
+				end = u
+				break
+			}
+		}
+		mask = prev
+	}
+	return path
+}
+
+func hasArc(arcs []int, v int) bool {
+	for _, a := range arcs {
+		if a == v {
+			return true
+		}
+	}
+	return false
+}
+
+// hamiltonianBacktrack finds a Hamiltonian path by depth-first search over
+// simple paths from each Node in turn.
+func hamiltonianBacktrack(adj [][]int) []int {
+	n := len(adj)
+	onPath := make([]bool, n)
+	path := make([]int, 0, n)
+	var extend func(u int) bool
+	extend = func(u int) bool {
+		onPath[u] = true
+		path = append(path, u)
+		if len(path) == n {
+			return true
+		}
+		for _, v := range adj[u] {
+			if !onPath[v] && extend(v) {
+				return true
+			}
+		}
+		onPath[u] = false
+		path = path[:len(path)-1]
+		return false
+	}
+	for start := 0; start < n; start++ {
+		if extend(start) {
+			return path
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package graph
+
+import "testing"
+
+// checkWalk returns true if walk uses each edge of g exactly once, counting
+// an undirected edge in either direction.
+func checkWalk(g *IntGraph, walk []Node) bool {
+	used := map[[2]Node]int{}
+	for i := 1; i < len(walk); i++ {
+		a, b := walk[i-1], walk[i]
+		if !a.HasNeighbor(b) {
+			return false
+		}
+		if g.Undirected() && ValueLess(b, a) {
+			a, b = b, a
+		}
+		used[[2]Node{a, b}]++
+	}
+	edges := 0
+	for _, n := range g.nodeList() {
+		for nbr := range n.Neighbors() {
+			if g.Undirected() && ValueLess(nbr, n) {
+				continue
+			}
+			edges++
+			if used[[2]Node{n, nbr}] != 1 {
+				return false
+			}
+		}
+	}
+	return edges == len(walk)-1
+}
+
+var eulerianTests = []struct {
+	nodes      []int
+	edges      [][]int
+	undirected bool
+	path       bool
+	circuit    bool
+}{
+	// A directed cycle with a chord back: 0->1->2->0, 2->3->2
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{1}, {2}, {0, 3}, {2}},
+		false,
+		true,
+		true,
+	},
+	// A directed path with one extra out-edge at 0 and in-edge at 3
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{1, 2}, {2}, {0, 3}, {}},
+		false,
+		true,
+		false,
+	},
+	// Node 0 has two extra out-edges
+	{
+		[]int{0, 1, 2},
+		[][]int{{1, 2}, {}, {}},
+		false,
+		false,
+		false,
+	},
+	// The undirected "house": a square with a roof, two odd nodes
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{{1, 3}, {2, 3, 4}, {3, 4}, {}, {}},
+		true,
+		true,
+		false,
+	},
+	// Two undirected triangles sharing node 0, all degrees even
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{{1, 2, 3, 4}, {2}, {}, {4}, {}},
+		true,
+		true,
+		true,
+	},
+	// A star of three leaves has four odd nodes
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{1, 2, 3}, {}, {}, {}},
+		true,
+		false,
+		false,
+	},
+	// An undirected self-loop adds 2 to the degree of node 1
+	{
+		[]int{0, 1},
+		[][]int{{1}, {1}},
+		true,
+		true,
+		false,
+	},
+	// An undirected triangle with a self-loop at 0, all degrees even
+	{
+		[]int{0, 1, 2},
+		[][]int{{0, 1, 2}, {2}, {}},
+		true,
+		true,
+		true,
+	},
+}
+
+func TestEulerian(t *testing.T) {
+	for _, tt := range eulerianTests {
+		g, nodes := newIntGraph(tt.nodes, tt.edges)
+		if tt.undirected {
+			g = NewUndirectedIntGraph()
+			nodes = make([]*IntNode, len(tt.nodes))
+			for i, v := range tt.nodes {
+				nodes[i] = NewIntNode(v)
+				g.Insert(nodes[i])
+			}
+			for i, es := range tt.edges {
+				for _, e := range es {
+					g.AddNeighbor(nodes[i], nodes[e])
+				}
+			}
+		}
+		path, err := g.EulerianPath()
+		if !tt.path {
+			if _, ok := err.(DegreeError); !ok {
+				t.Errorf("EulerianPath: expected DegreeError, actual %v", err)
+			}
+		} else if err != nil || !checkWalk(g, path) {
+			t.Errorf("EulerianPath: expected a walk over every edge, actual %v (%v)", pathValues(path), err)
+		}
+		circuit, err := g.EulerianCircuit()
+		if !tt.circuit {
+			if _, ok := err.(DegreeError); !ok {
+				t.Errorf("EulerianCircuit: expected DegreeError, actual %v", err)
+			}
+		} else if err != nil || !checkWalk(g, circuit) || circuit[0] != circuit[len(circuit)-1] {
+			t.Errorf("EulerianCircuit: expected a closed walk over every edge, actual %v (%v)", pathValues(circuit), err)
+		}
+	}
+}
+
+func TestEulerianDisconnected(t *testing.T) {
+	g, _ := newIntGraph([]int{0, 1, 2, 3}, [][]int{{1}, {0}, {3}, {2}})
+	if _, err := g.EulerianCircuit(); err == nil {
+		t.Errorf("EulerianCircuit: expected NotFoundError for disconnected edges, actual nil")
+	} else if _, ok := err.(NotFoundError); !ok {
+		t.Errorf("EulerianCircuit: expected NotFoundError, actual %v", err)
+	}
+}
+
+var hamiltonianTests = []struct {
+	nodes  []int
+	edges  [][]int
+	exists bool
+	degree bool
+}{
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{2}, {3}, {1}, {}},
+		true,
+		false,
+	},
+	{
+		[]int{0, 1, 2, 3},
+		[][]int{{1, 2}, {3}, {3}, {}},
+		false,
+		false,
+	},
+	{
+		[]int{0, 1, 2},
+		[][]int{{}, {}, {0}},
+		false,
+		true,
+	},
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][]int{{1, 2}, {2, 3}, {0, 4}, {4}, {3}},
+		true,
+		false,
+	},
+}
+
+func checkHamiltonian(g *IntGraph, path []Node) bool {
+	seen := map[Node]bool{}
+	for i, n := range path {
+		if seen[n] || (i > 0 && !path[i-1].HasNeighbor(n)) {
+			return false
+		}
+		seen[n] = true
+	}
+	return len(path) == g.Size()
+}
+
+func TestHamiltonianPath(t *testing.T) {
+	for _, tt := range hamiltonianTests {
+		g, _ := newIntGraph(tt.nodes, tt.edges)
+		path, err := g.HamiltonianPath()
+		switch {
+		case tt.exists:
+			if err != nil || !checkHamiltonian(g, path) {
+				t.Errorf("HamiltonianPath: expected a path, actual %v (%v)", pathValues(path), err)
+			}
+		case tt.degree:
+			if _, ok := err.(DegreeError); !ok {
+				t.Errorf("HamiltonianPath: expected DegreeError, actual %v", err)
+			}
+		default:
+			if _, ok := err.(NotFoundError); !ok {
+				t.Errorf("HamiltonianPath: expected NotFoundError, actual %v", err)
+			}
+		}
+	}
+}
+
+func TestHamiltonianBacktrack(t *testing.T) {
+	// Above the DP limit, a grid has a snaking Hamiltonian path
+	g := Grid(5, 5)
+	path, err := g.HamiltonianPath()
+	if err != nil || !checkHamiltonian(g, path) {
+		t.Errorf("HamiltonianPath: expected a path through 25 nodes, actual %v (%v)", pathValues(path), err)
+	}
+	// ... and a star with many leaves is rejected by degree
+	edges := make([][]int, 25)
+	values := make([]int, 25)
+	for i := range values {
+		values[i] = i
+		if i > 0 {
+			edges[0] = append(edges[0], i)
+			edges[i] = []int{0}
+		}
+	}
+	g, _ = newIntGraph(values, edges)
+	g.undirected = true
+	if _, err := g.HamiltonianPath(); err == nil {
+		t.Errorf("HamiltonianPath: expected DegreeError for a star, actual nil")
+	}
+}
@@ -0,0 +1,118 @@
+package unionfind
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DisjointSet implements a thread-safe union-find structure, partitioning
+// elements of type T into disjoint sets. Path compression and union by rank
+// keep Find, Union and Connected near constant time.
+type DisjointSet[T comparable] struct {
+	lock   sync.Mutex
+	parent map[T]T
+	rank   map[T]int
+	count  int
+}
+
+// New creates and returns a new *DisjointSet, with each of elems in a set of
+// its own.
+func New[T comparable](elems ...T) *DisjointSet[T] {
+	ds := &DisjointSet[T]{
+		parent: make(map[T]T, len(elems)),
+		rank:   make(map[T]int, len(elems)),
+	}
+	for _, x := range elems {
+		ds.add(x)
+	}
+	return ds
+}
+
+// Add puts x in a set of its own, if it is not already in the DisjointSet
+func (ds *DisjointSet[T]) Add(x T) {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	ds.add(x)
+}
+
+func (ds *DisjointSet[T]) add(x T) {
+	if _, ok := ds.parent[x]; ok {
+		return
+	}
+	ds.parent[x] = x
+	ds.count++
+}
+
+// Size returns the number of elements in the DisjointSet
+func (ds *DisjointSet[T]) Size() int {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	return len(ds.parent)
+}
+
+// ComponentCount returns the number of disjoint sets
+func (ds *DisjointSet[T]) ComponentCount() int {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	return ds.count
+}
+
+// Find returns the representative element of the set containing x
+func (ds *DisjointSet[T]) Find(x T) (T, error) {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	if _, ok := ds.parent[x]; !ok {
+		var zero T
+		return zero, fmt.Errorf("Element not found: %v", x)
+	}
+	return ds.find(x), nil
+}
+
+func (ds *DisjointSet[T]) find(x T) T {
+	root := x
+	for ds.parent[root] != root {
+		root = ds.parent[root]
+	}
+	for x != root {
+		next := ds.parent[x]
+		ds.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union merges the sets containing a and b, adding either to the DisjointSet
+// if it is not already present. It returns false if a and b were already in
+// the same set.
+func (ds *DisjointSet[T]) Union(a, b T) bool {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	ds.add(a)
+	ds.add(b)
+	ra, rb := ds.find(a), ds.find(b)
+	if ra == rb {
+		return false
+	}
+	if ds.rank[ra] < ds.rank[rb] {
+		ra, rb = rb, ra
+	}
+	ds.parent[rb] = ra
+	if ds.rank[ra] == ds.rank[rb] {
+		ds.rank[ra]++
+	}
+	ds.count--
+	return true
+}
+
+// Connected returns true if a and b are in the same set
+func (ds *DisjointSet[T]) Connected(a, b T) bool {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+	if _, ok := ds.parent[a]; !ok {
+		return false
+	}
+	if _, ok := ds.parent[b]; !ok {
+		return false
+	}
+	return ds.find(a) == ds.find(b)
+}
@@ -0,0 +1,71 @@
+package unionfind
+
+import "testing"
+
+var unionTests = []struct {
+	elems      []int
+	unions     [][2]int
+	connected  [][2]int
+	separate   [][2]int
+	components int
+}{
+	{
+		[]int{0, 1, 2, 3, 4},
+		[][2]int{},
+		[][2]int{{0, 0}},
+		[][2]int{{0, 1}, {3, 4}},
+		5,
+	},
+	{
+		[]int{0, 1, 2, 3, 4, 5},
+		[][2]int{{0, 1}, {1, 2}, {3, 4}, {2, 0}},
+		[][2]int{{0, 2}, {2, 1}, {4, 3}},
+		[][2]int{{0, 3}, {5, 4}},
+		3,
+	},
+	{
+		[]int{},
+		[][2]int{{7, 8}, {8, 9}},
+		[][2]int{{7, 9}},
+		[][2]int{{7, 10}},
+		1,
+	},
+}
+
+func TestUnion(t *testing.T) {
+	for _, tt := range unionTests {
+		ds := New(tt.elems...)
+		for _, u := range tt.unions {
+			ds.Union(u[0], u[1])
+		}
+		for _, c := range tt.connected {
+			if !ds.Connected(c[0], c[1]) {
+				t.Errorf("Connected(%v, %v): expected true, actual false", c[0], c[1])
+			}
+		}
+		for _, s := range tt.separate {
+			if ds.Connected(s[0], s[1]) {
+				t.Errorf("Connected(%v, %v): expected false, actual true", s[0], s[1])
+			}
+		}
+		if act := ds.ComponentCount(); act != tt.components {
+			t.Errorf("ComponentCount: expected %v, actual %v", tt.components, act)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	ds := New("a", "b", "c")
+	if ds.Union("a", "b") != true || ds.Union("b", "a") != false {
+		t.Errorf("Union: expected true then false")
+	}
+	ra, _ := ds.Find("a")
+	rb, _ := ds.Find("b")
+	rc, _ := ds.Find("c")
+	if ra != rb || ra == rc {
+		t.Errorf("Find: expected a and b to share a representative apart from c")
+	}
+	if _, err := ds.Find("d"); err == nil {
+		t.Errorf("Find: expected error for missing element, actual nil")
+	}
+}